@@ -0,0 +1,403 @@
+package table
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// TextTable is a table read from text, each row has one string per column
+type TextTable struct {
+	Columns []*Column
+	Rows    [][]string
+}
+
+// Spec returns a new TableSpec of Columns
+func (tt *TextTable) Spec() *TableSpec {
+	spec := NewTableSpec()
+	for _, col := range tt.Columns {
+		spec.AddColumn(col)
+	}
+	return spec
+}
+
+// Table returns a new Table of Spec, with column widths of titles and cells
+func (tt *TextTable) Table() *Table {
+	t := NewTable(tt.Spec())
+	widths := map[string]uint16{}
+	for i, col := range tt.Columns {
+		widths[col.Name] = visualWidth(col.Title)
+		for _, row := range tt.Rows {
+			if w := visualWidth(row[i]); w > widths[col.Name] {
+				widths[col.Name] = w
+			}
+		}
+	}
+	t.UpdateWidth(widths)
+	return t
+}
+
+// Items returns Rows as items for Getters of Columns
+func (tt *TextTable) Items() []any {
+	items := make([]any, len(tt.Rows))
+	for i, row := range tt.Rows {
+		items[i] = row
+	}
+	return items
+}
+
+// Len and Get implement FormattedItemList
+func (tt *TextTable) Len() int {
+	return len(tt.Rows)
+}
+
+func (tt *TextTable) Get(index int) []string {
+	return tt.Rows[index]
+}
+
+// textCellGetter is a Getter of column index of TextTable rows
+type textCellGetter struct {
+	index int
+}
+
+func (g textCellGetter) Value(item any) (any, error) {
+	row, ok := item.([]string)
+	if !ok {
+		return nil, fmt.Errorf("invalid item type %T, must be []string", item)
+	}
+	if g.index >= len(row) {
+		return "", nil
+	}
+	return row[g.index], nil
+}
+
+func (g textCellGetter) ValueString(colName string, item any) (string, error) {
+	value, err := g.Value(item)
+	if err != nil {
+		return "", err
+	}
+	return value.(string), nil
+}
+
+func (g textCellGetter) Format(item any, value any) (string, error) {
+	return fmt.Sprint(value), nil
+}
+
+var stringType = reflect.TypeOf("")
+
+// newTextColumns creates string columns with titles, duplicate and
+// empty titles get unique names
+func newTextColumns(titles []string) []*Column {
+	columns := make([]*Column, len(titles))
+	used := map[string]bool{}
+	for i, title := range titles {
+		name := title
+		if name == "" {
+			name = "column" + strconv.Itoa(i+1)
+		}
+		for n := 2; used[name]; n++ {
+			name = title + "_" + strconv.Itoa(n)
+		}
+		used[name] = true
+		columns[i] = &Column{
+			Type:      stringType,
+			Getter:    textCellGetter{index: i},
+			Alignment: AlignmentLeft,
+			Name:      name,
+			Title:     title,
+		}
+	}
+	return columns
+}
+
+// expandTabs replaces tabs with spaces up to the next multiple of 8
+func expandTabs(line string) string {
+	if !strings.Contains(line, "\t") {
+		return line
+	}
+	var sb strings.Builder
+	col := 0
+	for _, c := range line {
+		if c == '\t' {
+			n := 8 - col%8
+			sb.WriteString(strings.Repeat(" ", n))
+			col += n
+			continue
+		}
+		sb.WriteRune(c)
+		col++
+	}
+	return sb.String()
+}
+
+// isRuleLine returns true for lines like "----  -----" under the header
+func isRuleLine(line string) bool {
+	line = strings.TrimSpace(line)
+	return line != "" && strings.Trim(line, "-= ") == ""
+}
+
+// cellBlank returns true if display column col of row is a space
+// or beyond the end of row
+func cellBlank(row []screenCell, col int) bool {
+	if col >= len(row) {
+		return true
+	}
+	return !row[col].cont && strings.TrimSpace(row[col].text) == ""
+}
+
+// cellText returns trimmed text of display columns [start, end) of row
+func cellText(row []screenCell, start int, end int) string {
+	if end > len(row) || end < 0 {
+		end = len(row)
+	}
+	if start >= end {
+		return ""
+	}
+	var sb strings.Builder
+	for _, cell := range row[start:end] {
+		sb.WriteString(cell.text)
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// textSpan is a range [start, end) of display columns
+type textSpan struct {
+	start int
+	end   int
+}
+
+// nonBlankSpans returns runs of non-blank cells of row
+func nonBlankSpans(row []screenCell) []textSpan {
+	spans := []textSpan{}
+	for col := 0; col < len(row); col++ {
+		if cellBlank(row, col) {
+			continue
+		}
+		if n := len(spans); n > 0 && spans[n-1].end == col {
+			spans[n-1].end = col + 1
+			continue
+		}
+		spans = append(spans, textSpan{start: col, end: col + 1})
+	}
+	return spans
+}
+
+// ParseAligned parses a whitespace-aligned text table, like output of ps,
+// df, docker ps or WritePlain. The first non-empty line is the header.
+// Columns are detected from header words and gutters (display columns that
+// are blank in all lines), so titles like "CONTAINER ID" are kept as one
+// column and the last column may contain spaces. Escape sequences are
+// removed and wide (CJK) characters take two display columns. Alignment
+// of each column is inferred from positions of its cells
+func ParseAligned(r io.Reader) (*TextTable, error) {
+	grid := [][]screenCell{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		line := expandTabs(StripANSI(strings.TrimRight(scanner.Text(), "\r")))
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if len(grid) == 1 && isRuleLine(line) {
+			continue
+		}
+		grid = append(grid, parseScreen(line)[0])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(grid) == 0 {
+		return &TextTable{Columns: []*Column{}, Rows: [][]string{}}, nil
+	}
+	return parseAlignedGrid(grid[0], grid[1:]), nil
+}
+
+func parseAlignedGrid(header []screenCell, rows [][]screenCell) *TextTable {
+	width := len(header)
+	for _, row := range rows {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+	gutter := make([]bool, width+1)
+	for col := range gutter {
+		gutter[col] = cellBlank(header, col)
+		for _, row := range rows {
+			if !cellBlank(row, col) {
+				gutter[col] = false
+				break
+			}
+		}
+	}
+	// header words separated by non-gutter spaces belong to one title
+	spans := []textSpan{}
+	for _, span := range nonBlankSpans(header) {
+		n := len(spans)
+		if n == 0 {
+			spans = append(spans, span)
+			continue
+		}
+		merge := true
+		for col := spans[n-1].end; col < span.start; col++ {
+			if gutter[col] {
+				merge = false
+				break
+			}
+		}
+		if merge {
+			spans[n-1].end = span.end
+			continue
+		}
+		spans = append(spans, span)
+	}
+	// cut between columns at a gutter, preferably between titles
+	cuts := make([]int, len(spans)+1)
+	for i := 1; i < len(spans); i++ {
+		cuts[i] = alignedCut(gutter, spans[i-1], spans[i])
+	}
+	cuts[len(spans)] = -1
+	// a column with no data is a part of the previous title, like "on"
+	// in "Mounted on" if all mount points are short
+	for i := len(spans) - 1; i > 0; i-- {
+		empty := true
+		for _, row := range rows {
+			if cellText(row, cuts[i], cuts[i+1]) != "" {
+				empty = false
+				break
+			}
+		}
+		if !empty || spans[i].start-spans[i-1].end != 1 {
+			continue
+		}
+		spans[i-1].end = spans[i].end
+		spans = append(spans[:i], spans[i+1:]...)
+		cuts = append(cuts[:i], cuts[i+1:]...)
+	}
+	titles := make([]string, len(spans))
+	for i, span := range spans {
+		titles[i] = cellText(header, span.start, span.end)
+	}
+	parsed := &TextTable{
+		Columns: newTextColumns(titles),
+		Rows:    make([][]string, len(rows)),
+	}
+	for rowI, row := range rows {
+		cells := make([]string, len(spans))
+		for i := range spans {
+			cells[i] = cellText(row, cuts[i], cuts[i+1])
+		}
+		parsed.Rows[rowI] = cells
+	}
+	for i, col := range parsed.Columns {
+		col.Alignment = inferAlignment(header, rows, spans[i], cuts[i], cuts[i+1])
+	}
+	return parsed
+}
+
+// alignedCut returns the display column to split a line between two
+// columns with title spans left and right
+func alignedCut(gutter []bool, left textSpan, right textSpan) int {
+	for col := left.end; col < right.start; col++ {
+		if gutter[col] {
+			return col
+		}
+	}
+	// cells overflow the space between titles, find a gutter
+	// under the titles
+	for col := right.start - 1; col > left.start; col-- {
+		if gutter[col] {
+			return col
+		}
+	}
+	for col := right.start; col < right.end; col++ {
+		if gutter[col] {
+			return col
+		}
+	}
+	return left.end
+}
+
+// isNumber returns true if str is a number, possibly with a unit suffix
+// or percent sign, like "-1.5", "42%" or "1.2G"
+func isNumber(str string) bool {
+	str = strings.TrimRight(str, "%KMGTPkmgtpBbi")
+	str = strings.ReplaceAll(str, ",", "")
+	if str == "" {
+		return false
+	}
+	_, err := strconv.ParseFloat(str, 64)
+	return err == nil
+}
+
+// inferAlignment infers alignment of cells of a column from display
+// columns [start, end), title span is used when cells do not decide it
+func inferAlignment(header []screenCell, rows [][]screenCell, title textSpan, start int, end int) Alignment {
+	cellSpans := []textSpan{}
+	numeric := true
+	for _, row := range rows {
+		stop := end
+		if stop < 0 || stop > len(row) {
+			stop = len(row)
+		}
+		if start >= stop {
+			continue
+		}
+		spans := nonBlankSpans(row[start:stop])
+		if len(spans) == 0 {
+			continue
+		}
+		span := textSpan{start: start + spans[0].start, end: start + spans[len(spans)-1].end}
+		cellSpans = append(cellSpans, span)
+		if !isNumber(cellText(row, span.start, span.end)) {
+			numeric = false
+		}
+	}
+	if len(cellSpans) == 0 {
+		return AlignmentLeft
+	}
+	sameStart, sameEnd := true, true
+	minStart, maxEnd := title.start, title.end
+	for _, span := range cellSpans {
+		if span.start != cellSpans[0].start {
+			sameStart = false
+		}
+		if span.end != cellSpans[0].end {
+			sameEnd = false
+		}
+		if span.start < minStart {
+			minStart = span.start
+		}
+		if span.end > maxEnd {
+			maxEnd = span.end
+		}
+	}
+	switch {
+	case sameStart && !sameEnd:
+		return AlignmentLeft
+	case sameEnd && !sameStart:
+		return AlignmentRight
+	case sameStart && sameEnd:
+		if numeric || cellSpans[0].end == title.end && cellSpans[0].start != title.start {
+			return AlignmentRight
+		}
+		return AlignmentLeft
+	}
+	centered := true
+	for _, span := range cellSpans {
+		diff := (span.start - minStart) - (maxEnd - span.end)
+		if diff < -1 || diff > 1 {
+			centered = false
+			break
+		}
+	}
+	switch {
+	case centered:
+		return AlignmentCenter
+	case numeric:
+		return AlignmentRight
+	}
+	return AlignmentLeft
+}
@@ -0,0 +1,115 @@
+package table
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+func alignmentNames(columns []*Column) []string {
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = alignmentName(col.Alignment)
+	}
+	return names
+}
+
+func columnTitles(columns []*Column) []string {
+	titles := make([]string, len(columns))
+	for i, col := range columns {
+		titles[i] = col.Title
+	}
+	return titles
+}
+
+func TestParseAlignedPS(t *testing.T) {
+	is := is.New(t)
+	parsed, err := ParseAligned(strings.NewReader(`  PID TTY          TIME CMD
+    1 ?        00:00:03 /sbin/init splash
+  412 pts/0    00:00:00 bash
+10977 pts/0    00:00:00 ps aux
+`))
+	is.NotErr(err)
+	is.Equal(columnTitles(parsed.Columns), []string{"PID", "TTY", "TIME", "CMD"})
+	is.Equal(alignmentNames(parsed.Columns), []string{"right", "left", "right", "left"})
+	is.Equal(parsed.Rows, [][]string{
+		{"1", "?", "00:00:03", "/sbin/init splash"},
+		{"412", "pts/0", "00:00:00", "bash"},
+		{"10977", "pts/0", "00:00:00", "ps aux"},
+	})
+}
+
+func TestParseAlignedMultiWordTitles(t *testing.T) {
+	is := is.New(t)
+	parsed, err := ParseAligned(strings.NewReader(`Filesystem     1K-blocks     Used Available Use% Mounted on
+/dev/sda1       48254668 30000000  15789012  66% /
+tmpfs            8000000        0   8000000   0% /dev/shm
+`))
+	is.NotErr(err)
+	is.Equal(columnTitles(parsed.Columns), []string{"Filesystem", "1K-blocks", "Used", "Available", "Use%", "Mounted on"})
+	is.Equal(alignmentNames(parsed.Columns), []string{"left", "right", "right", "right", "right", "left"})
+	is.Equal(parsed.Rows[1], []string{"tmpfs", "8000000", "0", "8000000", "0%", "/dev/shm"})
+
+	parsed, err = ParseAligned(strings.NewReader(`CONTAINER ID   IMAGE          STATUS
+3f4e5d6c7b8a   nginx:latest   Up 2 hours
+a1b2c3d4e5f6   redis          Exited (0) 3 days ago
+`))
+	is.NotErr(err)
+	is.Equal(columnTitles(parsed.Columns), []string{"CONTAINER ID", "IMAGE", "STATUS"})
+	is.Equal(parsed.Rows[1], []string{"a1b2c3d4e5f6", "redis", "Exited (0) 3 days ago"})
+}
+
+func TestParseAlignedRuleLine(t *testing.T) {
+	is := is.New(t)
+	parsed, err := ParseAligned(strings.NewReader("name  size\n----  ----\na\t1\nbb   22\n"))
+	is.NotErr(err)
+	is.Equal(columnTitles(parsed.Columns), []string{"name", "size"})
+	is.Equal(parsed.Rows, [][]string{{"a", "1"}, {"bb", "22"}})
+
+	parsed, err = ParseAligned(strings.NewReader(""))
+	is.NotErr(err)
+	is.Equal(len(parsed.Columns), 0)
+	is.Equal(len(parsed.Rows), 0)
+}
+
+func TestParseAlignedRoundTrip(t *testing.T) {
+	is := is.New(t)
+	tab := newTestTable("name", "size", "kind")
+	tab.ColumnByName["size"].Alignment = AlignmentRight
+	tab.ColumnByName["kind"].Alignment = AlignmentCenter
+	tab.UpdateWidth(map[string]uint16{"name": 4, "size": 4, "kind": 4})
+	tab.SetColor(true)
+	tab.AddStyleRule(&StyleRule{
+		Column: "name",
+		Match:  func(value any) bool { return value == "さの.png" },
+		Style:  Style{Fg: Red},
+	})
+	items := [][]any{
+		{"README.md", 1523, "file"},
+		{"さの.png", 87, "image"},
+		{"src", 4096, "dir"},
+	}
+	formatted := FormattedItems{}
+	for _, item := range items {
+		row, err := tab.FormatItem(item)
+		is.NotErr(err)
+		formatted = append(formatted, row)
+	}
+	buf := &bytes.Buffer{}
+	is.NotErr(tab.WritePlain(buf, formatted, "  "))
+
+	parsed, err := ParseAligned(buf)
+	is.NotErr(err)
+	is.Equal(columnTitles(parsed.Columns), []string{"name", "size", "kind"})
+	is.Equal(alignmentNames(parsed.Columns), []string{"left", "right", "center"})
+	is.Equal(parsed.Rows, [][]string{
+		{"README.md", "1523", "file"},
+		{"さの.png", "87", "image"},
+		{"src", "4096", "dir"},
+	})
+	value, err := parsed.Columns[1].Getter.Value(parsed.Rows[2])
+	is.NotErr(err)
+	is.Equal(value, "4096")
+}
@@ -1,6 +1,9 @@
 package table
 
-import "strings"
+import (
+	"reflect"
+	"strings"
+)
 
 const alignSep = " "
 
@@ -32,3 +35,21 @@ func AlignmentCenter(str string, width uint16) string {
 	right := n - left
 	return strings.Repeat(alignSep, left) + str + strings.Repeat(" ", right)
 }
+
+// alignmentName returns "left", "right" or "center" for built-in
+// alignments, and "" for nil or custom alignments
+func alignmentName(al Alignment) string {
+	if al == nil {
+		return ""
+	}
+	ptr := reflect.ValueOf(al).Pointer()
+	switch ptr {
+	case reflect.ValueOf(AlignmentLeft).Pointer():
+		return "left"
+	case reflect.ValueOf(AlignmentRight).Pointer():
+		return "right"
+	case reflect.ValueOf(AlignmentCenter).Pointer():
+		return "center"
+	}
+	return ""
+}
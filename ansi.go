@@ -0,0 +1,316 @@
+package table
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ilius/go-table/runewidth"
+	"github.com/ilius/go-table/runewidth/uniseg"
+)
+
+type TokenKind uint8
+
+const (
+	// TokenText is visible text (may include C0 control characters)
+	TokenText TokenKind = iota
+	// TokenCSI is a Control Sequence Introducer sequence, like SGR colors
+	TokenCSI
+	// TokenOSC is an Operating System Command, like OSC 8 hyperlinks
+	// or window titles, terminated by BEL or ST
+	TokenOSC
+	// TokenDCS is a Device Control String, terminated by ST
+	TokenDCS
+	// TokenString is a SOS, PM or APC string, terminated by ST
+	TokenString
+	// TokenEscape is any other escape sequence, like ESC ( B
+	TokenEscape
+)
+
+// Token is a run of text or a single control sequence
+type Token struct {
+	Text string
+	Kind TokenKind
+}
+
+const (
+	esc = 0x1b
+	bel = 0x07
+)
+
+// C1 control characters, in 8-bit form
+const (
+	c1DCS = 0x90
+	c1SOS = 0x98
+	c1CSI = 0x9b
+	c1ST  = 0x9c
+	c1OSC = 0x9d
+	c1PM  = 0x9e
+	c1APC = 0x9f
+)
+
+// c1At returns the C1 control character at start of s (encoded as UTF-8
+// or as a raw 8-bit byte) and its length in bytes, or 0, 0
+func c1At(s string) (byte, int) {
+	if s == "" || s[0] < 0x80 {
+		return 0, 0
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError && size == 1 {
+		if s[0] <= 0x9f {
+			return s[0], 1
+		}
+		return 0, 0
+	}
+	if r >= 0x80 && r <= 0x9f {
+		return byte(r), size
+	}
+	return 0, 0
+}
+
+// stringEnd returns the end index of a control string starting at start,
+// allowBEL is true for OSC which may be terminated by BEL as well as ST.
+// If not terminated, the rest of s is consumed.
+func stringEnd(s string, start int, allowBEL bool) int {
+	for i := start; i < len(s); {
+		switch {
+		case s[i] == bel && allowBEL:
+			return i + 1
+		case s[i] == esc && i+1 < len(s) && s[i+1] == '\\':
+			return i + 2
+		case s[i] == esc:
+			// an ESC that is not ST cancels the control string
+			return i
+		}
+		if c, size := c1At(s[i:]); c == c1ST {
+			return i + size
+		} else if size > 0 {
+			i += size
+			continue
+		}
+		i++
+	}
+	return len(s)
+}
+
+// csiEnd returns the end index of a CSI sequence whose parameters start at start
+func csiEnd(s string, start int) int {
+	for i := start; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 0x20 && c <= 0x3f:
+			// parameter and intermediate bytes
+		case c >= 0x40 && c <= 0x7e:
+			return i + 1
+		default:
+			// invalid byte aborts the sequence
+			return i
+		}
+	}
+	return len(s)
+}
+
+// controlEnd returns the kind and end index of the control sequence
+// starting at start, or ok=false if there is none
+func controlEnd(s string, start int) (kind TokenKind, end int, ok bool) {
+	if s[start] == esc {
+		if start+1 >= len(s) {
+			return TokenEscape, len(s), true
+		}
+		switch s[start+1] {
+		case '[':
+			return TokenCSI, csiEnd(s, start+2), true
+		case ']':
+			return TokenOSC, stringEnd(s, start+2, true), true
+		case 'P':
+			return TokenDCS, stringEnd(s, start+2, false), true
+		case 'X', '^', '_':
+			return TokenString, stringEnd(s, start+2, false), true
+		}
+		i := start + 1
+		for i < len(s) && s[i] >= 0x20 && s[i] <= 0x2f {
+			i++
+		}
+		if i < len(s) && s[i] >= 0x30 && s[i] <= 0x7e {
+			i++
+		}
+		return TokenEscape, i, true
+	}
+	c, size := c1At(s[start:])
+	switch c {
+	case 0:
+		return 0, 0, false
+	case c1CSI:
+		return TokenCSI, csiEnd(s, start+size), true
+	case c1OSC:
+		return TokenOSC, stringEnd(s, start+size, true), true
+	case c1DCS:
+		return TokenDCS, stringEnd(s, start+size, false), true
+	case c1SOS, c1PM, c1APC:
+		return TokenString, stringEnd(s, start+size, false), true
+	}
+	return TokenEscape, start + size, true
+}
+
+// ForEachToken calls f for each token of str, in order
+func ForEachToken(str string, f func(tok Token)) {
+	textStart := 0
+	for i := 0; i < len(str); {
+		if str[i] != esc && str[i] < 0x80 {
+			i++
+			continue
+		}
+		kind, end, ok := controlEnd(str, i)
+		if !ok {
+			_, size := utf8.DecodeRuneInString(str[i:])
+			i += size
+			continue
+		}
+		if i > textStart {
+			f(Token{Kind: TokenText, Text: str[textStart:i]})
+		}
+		f(Token{Kind: kind, Text: str[i:end]})
+		i = end
+		textStart = end
+	}
+	if textStart < len(str) {
+		f(Token{Kind: TokenText, Text: str[textStart:]})
+	}
+}
+
+// Tokenize splits str into text runs and control sequences
+func Tokenize(str string) []Token {
+	tokens := []Token{}
+	ForEachToken(str, func(tok Token) {
+		tokens = append(tokens, tok)
+	})
+	return tokens
+}
+
+// StripANSI removes all escape / control sequences from str
+func StripANSI(str string) string {
+	var sb strings.Builder
+	ForEachToken(str, func(tok Token) {
+		if tok.Kind == TokenText {
+			sb.WriteString(tok.Text)
+		}
+	})
+	return sb.String()
+}
+
+// controlParams returns the text of a control sequence after its
+// introducer: ESC and escIntro, or the C1 character c (encoded as UTF-8
+// or as a raw 8-bit byte). ok is false for other introducers
+func controlParams(seq string, c byte, escIntro byte) (string, bool) {
+	if len(seq) >= 2 && seq[0] == esc && seq[1] == escIntro {
+		return seq[2:], true
+	}
+	if c1, size := c1At(seq); size > 0 && c1 == c {
+		return seq[size:], true
+	}
+	return "", false
+}
+
+// trimTerminator removes BEL or ST (in any form) from the end of
+// a control string
+func trimTerminator(str string) string {
+	for _, term := range []string{"\x1b\\", "\x07", "\u009c"} {
+		if strings.HasSuffix(str, term) {
+			return str[:len(str)-len(term)]
+		}
+	}
+	// raw 8-bit ST, not the last byte of a UTF-8 character
+	if r, size := utf8.DecodeLastRuneInString(str); r == utf8.RuneError && size == 1 && str[len(str)-1] == c1ST {
+		return str[:len(str)-1]
+	}
+	return str
+}
+
+// isSGR returns true if tok is a Select Graphic Rendition sequence
+func isSGR(tok Token) bool {
+	return tok.Kind == TokenCSI && strings.HasSuffix(tok.Text, "m")
+}
+
+// isSGRReset returns true if tok is "ESC [ m" or "ESC [ 0 m" (or the
+// same with the C1 form of CSI)
+func isSGRReset(tok Token) bool {
+	params, ok := controlParams(tok.Text, c1CSI, '[')
+	return ok && (params == "m" || params == "0m")
+}
+
+// TruncateWidth truncates visible text of str to at most width cells,
+// keeping all control sequences (so colors are still reset)
+func TruncateWidth(str string, width uint16) string {
+	var sb strings.Builder
+	remaining := int(width)
+	ForEachToken(str, func(tok Token) {
+		if tok.Kind != TokenText {
+			sb.WriteString(tok.Text)
+			return
+		}
+		text := tok.Text
+		state := -1
+		for text != "" && remaining > 0 {
+			var cluster string
+			cluster, text, _, state = uniseg.StepString(text, state)
+			w := runewidth.StringWidth(cluster)
+			if w > remaining {
+				remaining = 0
+				break
+			}
+			remaining -= w
+			sb.WriteString(cluster)
+		}
+	})
+	return sb.String()
+}
+
+// WrapWidth splits str into lines of at most width cells of visible text,
+// at newlines and between grapheme clusters (a cluster wider than width
+// gets a line of its own). Control sequences are kept, and SGR styles that
+// are active at the end of a line are reset there and applied again at
+// the start of the next line
+func WrapWidth(str string, width uint16) []string {
+	lines := []string{}
+	var sb strings.Builder
+	used := 0
+	// SGR sequences since the last reset
+	styles := ""
+	breakLine := func() {
+		if styles != "" {
+			sb.WriteString("\x1b[0m")
+		}
+		lines = append(lines, sb.String())
+		sb.Reset()
+		sb.WriteString(styles)
+		used = 0
+	}
+	ForEachToken(str, func(tok Token) {
+		if tok.Kind != TokenText {
+			switch {
+			case isSGRReset(tok):
+				styles = ""
+			case isSGR(tok):
+				styles += tok.Text
+			}
+			sb.WriteString(tok.Text)
+			return
+		}
+		text := tok.Text
+		state := -1
+		for text != "" {
+			var cluster string
+			cluster, text, _, state = uniseg.StepString(text, state)
+			if cluster == "\n" || cluster == "\r\n" {
+				breakLine()
+				continue
+			}
+			w := runewidth.StringWidth(cluster)
+			if used > 0 && used+w > int(width) {
+				breakLine()
+			}
+			sb.WriteString(cluster)
+			used += w
+		}
+	})
+	return append(lines, sb.String())
+}
@@ -0,0 +1,79 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestTokenize(t *testing.T) {
+	is := is.New(t)
+	link := "\x1b]8;;https://example.com\x1b\\"
+	linkEnd := "\x1b]8;;\x07"
+	is.Equal(Tokenize(Fg(1)+"ab"+reset+link+"さの"+linkEnd), []Token{
+		{Kind: TokenCSI, Text: Fg(1)},
+		{Kind: TokenText, Text: "ab"},
+		{Kind: TokenCSI, Text: reset},
+		{Kind: TokenOSC, Text: link},
+		{Kind: TokenText, Text: "さの"},
+		{Kind: TokenOSC, Text: linkEnd},
+	})
+	is.Equal(Tokenize("\x1bP1$r\x1b\\x\x1b(By\u009b31mz\u009d0;title\u009c"), []Token{
+		{Kind: TokenDCS, Text: "\x1bP1$r\x1b\\"},
+		{Kind: TokenText, Text: "x"},
+		{Kind: TokenEscape, Text: "\x1b(B"},
+		{Kind: TokenText, Text: "y"},
+		{Kind: TokenCSI, Text: "\u009b31m"},
+		{Kind: TokenText, Text: "z"},
+		{Kind: TokenOSC, Text: "\u009d0;title\u009c"},
+	})
+	// unterminated OSC consumes the rest
+	is.Equal(Tokenize("a\x1b]0;title"), []Token{
+		{Kind: TokenText, Text: "a"},
+		{Kind: TokenOSC, Text: "\x1b]0;title"},
+	})
+}
+
+func TestVisualWidthControlSequences(t *testing.T) {
+	is := is.New(t)
+	link := "\x1b]8;;https://example.com/path?q=1\x1b\\"
+	linkEnd := "\x1b]8;;\x1b\\"
+	is.Equal(visualWidth(link+"さの.png"+linkEnd), uint16(8))
+	is.Equal(visualWidth("\x1b]2;window title\x07abc"), uint16(3))
+	is.Equal(visualWidth("\u009b1mabc\u009b0m"), uint16(3))
+}
+
+func TestStripAndTruncate(t *testing.T) {
+	is := is.New(t)
+	str := Fg(1) + "さの" + Fg(2) + ".png" + reset
+	is.Equal(StripANSI(str), "さの.png")
+	is.Equal(TruncateWidth(str, 5), Fg(1)+"さの"+Fg(2)+"."+reset)
+	is.Equal(TruncateWidth(str, 3), Fg(1)+"さ"+Fg(2)+reset)
+	is.Equal(TruncateWidth("abc", 10), "abc")
+}
+
+func TestWrapWidth(t *testing.T) {
+	is := is.New(t)
+	str := Fg(1) + "さの" + Fg(2) + ".png" + reset
+	is.Equal(WrapWidth(str, 3), []string{
+		Fg(1) + "さ" + reset,
+		Fg(1) + "の" + Fg(2) + "." + reset,
+		Fg(1) + Fg(2) + "png" + reset,
+	})
+	is.Equal(WrapWidth("ab\ncde", 2), []string{"ab", "cd", "e"})
+	is.Equal(WrapWidth("さ", 1), []string{"さ"})
+	is.Equal(WrapWidth("", 5), []string{""})
+}
+
+func TestControlParams(t *testing.T) {
+	is := is.New(t)
+	for _, csi := range []string{"\x1b[", "\u009b", "\x9b"} {
+		is.True(isSGRReset(Token{Kind: TokenCSI, Text: csi + "0m"}))
+		is.True(isSGRReset(Token{Kind: TokenCSI, Text: csi + "m"}))
+		is.False(isSGRReset(Token{Kind: TokenCSI, Text: csi + "1m"}))
+	}
+	is.Equal(trimTerminator("0;title\x9c"), "0;title")
+	is.Equal(trimTerminator("0;title\u009c"), "0;title")
+	// last byte of "ќ" is 0x9c
+	is.Equal(trimTerminator("0;ќ"), "0;ќ")
+}
@@ -0,0 +1,48 @@
+package table
+
+import (
+	"io"
+	"strings"
+)
+
+var asciidocEscaper = strings.NewReplacer("|", `\|`)
+
+func asciidocColumnSpec(al Alignment) string {
+	switch alignmentName(al) {
+	case "right":
+		return ">"
+	case "center":
+		return "^"
+	}
+	return "<"
+}
+
+// WriteAsciiDoc writes items as an AsciiDoc table with a header row,
+// Column.Alignment is given in cols attribute
+func (t *Table) WriteAsciiDoc(out io.Writer, items []any) error {
+	header, rows, widths, err := t.textCells(items, asciidocEscaper.Replace)
+	if err != nil {
+		return err
+	}
+	specs := make([]string, t.ColumnCount())
+	for i, col := range t.Columns {
+		specs[i] = asciidocColumnSpec(col.Alignment)
+	}
+	row := func(cells []string) string {
+		return strings.TrimRight("| "+strings.Join(cells, " | "), " ")
+	}
+	for i, cell := range header {
+		header[i] = AlignmentLeft(cell, widths[i])
+	}
+	lines := []string{
+		`[cols="` + strings.Join(specs, ",") + `",options="header"]`,
+		"|===",
+		row(header),
+	}
+	for _, cells := range rows {
+		lines = append(lines, row(t.alignRow(cells, widths)))
+	}
+	lines = append(lines, "|===")
+	_, err = io.WriteString(out, strings.Join(lines, "\n")+"\n")
+	return err
+}
@@ -0,0 +1,272 @@
+package table
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Border is the set of strings used to draw table borders by WriteBordered
+type Border struct {
+	Horizontal string
+	Vertical   string
+
+	TopLeft  string
+	TopMid   string
+	TopRight string
+
+	MidLeft  string
+	MidMid   string
+	MidRight string
+
+	BottomLeft  string
+	BottomMid   string
+	BottomRight string
+}
+
+var BorderASCII = &Border{
+	Horizontal:  "-",
+	Vertical:    "|",
+	TopLeft:     "+",
+	TopMid:      "+",
+	TopRight:    "+",
+	MidLeft:     "+",
+	MidMid:      "+",
+	MidRight:    "+",
+	BottomLeft:  "+",
+	BottomMid:   "+",
+	BottomRight: "+",
+}
+
+var BorderLight = &Border{
+	Horizontal:  "─",
+	Vertical:    "│",
+	TopLeft:     "┌",
+	TopMid:      "┬",
+	TopRight:    "┐",
+	MidLeft:     "├",
+	MidMid:      "┼",
+	MidRight:    "┤",
+	BottomLeft:  "└",
+	BottomMid:   "┴",
+	BottomRight: "┘",
+}
+
+// borderedWidths returns width of each column, which is the width of
+// column title if there are no items
+func (t *Table) borderedWidths() []uint16 {
+	widths := make([]uint16, t.ColumnCount())
+	for i, col := range t.Columns {
+		widths[i] = t.Width(col.Name)
+		if widths[i] == 0 {
+			widths[i] = visualWidth(col.Title)
+		}
+	}
+	return widths
+}
+
+// borderRule returns a horizontal border line between rows with vertical
+// borders upper and lower (nil for the top and bottom lines), see
+// spanGrid.boundaries. Columns where horizontal is false are blank,
+// as their cell continues below the line
+func (t *Table) borderRule(widths []uint16, border *Border, upper []bool, lower []bool, horizontal []bool) string {
+	var sb strings.Builder
+	for col := 0; col <= len(widths); col++ {
+		sb.WriteString(border.junction(
+			upper != nil && upper[col],
+			lower != nil && lower[col],
+			col > 0 && horizontal[col-1],
+			col < len(widths) && horizontal[col],
+		))
+		if col == len(widths) {
+			break
+		}
+		segment := " "
+		if horizontal[col] {
+			segment = border.Horizontal
+		}
+		sb.WriteString(strings.Repeat(segment, int(widths[col])+2))
+	}
+	style := t.themeStyle(func(theme *Theme) Style { return theme.Border })
+	return style.apply(sb.String(), t.outputColorLevel())
+}
+
+func (t *Table) borderRow(cells []string, border *Border) string {
+	style := t.themeStyle(func(theme *Theme) Style { return theme.Border })
+	vertical := style.apply(border.Vertical, t.outputColorLevel())
+	return vertical + " " + strings.Join(cells, " "+vertical+" ") + " " + vertical
+}
+
+// allTrue returns a slice of n true values
+func allTrue(n int) []bool {
+	values := make([]bool, n)
+	for i := range values {
+		values[i] = true
+	}
+	return values
+}
+
+// borderedHeaderRows returns lines of HeaderRows and boundaries of each
+// row, and the flags of horizontal lines below each row
+func (t *Table) borderedHeaderRows(widths []uint16, levels [][]int, border *Border) ([]string, [][]bool, [][]bool) {
+	headerStyle := t.themeStyle(func(theme *Theme) Style { return theme.Header })
+	lines := make([]string, len(levels))
+	bounds := make([][]bool, len(levels))
+	horizontals := make([][]bool, len(levels))
+	for rowI, level := range levels {
+		colN := len(level)
+		bounds[rowI] = allTrue(colN + 1)
+		horizontals[rowI] = make([]bool, colN)
+		cells := []string{}
+		for start := 0; start < colN; {
+			end := start + 1
+			groupI := level[start]
+			if groupI >= 0 {
+				for end < colN && level[end] == groupI {
+					bounds[rowI][end] = false
+					end++
+				}
+			}
+			title := ""
+			if groupI >= 0 {
+				title = t.HeaderRows[rowI][groupI].Title
+				for col := start; col < end; col++ {
+					horizontals[rowI][col] = true
+				}
+			}
+			width := mergedWidth(widths[start:end], 3)
+			cells = append(cells, headerStyle.apply(AlignmentCenter(title, width), t.outputColorLevel()))
+			start = end
+		}
+		lines[rowI] = t.borderRow(cells, border)
+	}
+	return lines, bounds, horizontals
+}
+
+// spannedWidths sets widths of columns where a merged cell of several
+// columns starts to widths of other cells (FormatItem has added widths of
+// merged cells to their first column), then widens columns of merged
+// cells wider than their columns
+func (t *Table) spannedWidths(widths []uint16, items FormattedItemList, grid *spanGrid) {
+	merged := []cellPos{}
+	for pos, size := range grid.size {
+		if size.col > 1 {
+			merged = append(merged, pos)
+		}
+	}
+	if len(merged) == 0 {
+		return
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		if merged[i].row != merged[j].row {
+			return merged[i].row < merged[j].row
+		}
+		return merged[i].col < merged[j].col
+	})
+	for _, pos := range merged {
+		widths[pos.col] = 0
+	}
+	for _, pos := range merged {
+		col := pos.col
+		if widths[col] > 0 {
+			continue
+		}
+		widths[col] = visualWidth(t.Columns[col].Title)
+		for row := 0; row < items.Len(); row++ {
+			owner := grid.owner[row][col]
+			if _, cols := grid.span(owner); cols > 1 || owner.row != row {
+				continue
+			}
+			if w := visualWidth(items.Get(row)[col]); w > widths[col] {
+				widths[col] = w
+			}
+		}
+	}
+	for _, pos := range merged {
+		_, cols := grid.span(pos)
+		spreadWidth(widths[pos.col:pos.col+cols], visualWidth(items.Get(pos.row)[pos.col]), 3)
+	}
+}
+
+// WriteBordered writes header and items surrounded by border,
+// border is colored by Theme.Border and rows are striped if Theme is set.
+// Rows of grouped titles (see TableSpec.AddHeaderRow) are written above
+// column titles and cell spans (see AddCellSpan) are merged, columns are
+// widened if a group title or merged cell is wider than its columns
+func (t *Table) WriteBordered(out io.Writer, items FormattedItemList, border *Border) error {
+	if border == nil {
+		border = BorderLight
+	}
+	itemN := items.Len()
+	grid, err := t.spanGrid(itemN)
+	if err != nil {
+		return err
+	}
+	levels, err := t.headerGroupIndex()
+	if err != nil {
+		return err
+	}
+	colN := t.ColumnCount()
+	widths := t.borderedWidths()
+	t.spannedWidths(widths, items, grid)
+	for _, groups := range t.HeaderRows {
+		for _, group := range groups {
+			start := t.columnIndex(group.Columns[0])
+			spreadWidth(widths[start:start+len(group.Columns)], visualWidth(group.Title), 3)
+		}
+	}
+	headerStyle := t.themeStyle(func(theme *Theme) Style { return theme.Header })
+	header := make([]string, colN)
+	for i, col := range t.Columns {
+		header[i] = headerStyle.apply(t.padTitle(col, widths[i]), t.outputColorLevel())
+	}
+	full := allTrue(colN + 1)
+	horizontal := allTrue(colN)
+	groupLines, groupBounds, groupHorizontals := t.borderedHeaderRows(widths, levels, border)
+	upper := []bool(nil)
+	lines := []string{}
+	for rowI, line := range groupLines {
+		lines = append(lines, t.borderRule(widths, border, upper, groupBounds[rowI], horizontal), line)
+		upper, horizontal = groupBounds[rowI], groupHorizontals[rowI]
+	}
+	lines = append(lines, t.borderRule(widths, border, upper, full, horizontal), t.borderRow(header, border))
+	lower := full
+	if itemN > 0 {
+		lower = grid.boundaries(0)
+	}
+	lines = append(lines, t.borderRule(widths, border, full, lower, allTrue(colN)))
+	for _, line := range lines {
+		_, err := fmt.Fprintln(out, line)
+		if err != nil {
+			return err
+		}
+	}
+	for itemIdx := 0; itemIdx < itemN; itemIdx++ {
+		item := items.Get(itemIdx)
+		cells := make([]string, 0, len(item))
+		for col := 0; col < colN; {
+			owner := grid.owner[itemIdx][col]
+			end := col + 1
+			for end < colN && grid.owner[itemIdx][end] == owner {
+				end++
+			}
+			text := ""
+			if owner.row == itemIdx {
+				text = item[owner.col]
+			}
+			cells = append(cells, t.alignCell(col, text, mergedWidth(widths[col:end], 3)))
+			col = end
+		}
+		_, err := fmt.Fprintln(out, t.styleRow(t.borderRow(cells, border), itemIdx))
+		if err != nil {
+			return err
+		}
+	}
+	upper = full
+	if itemN > 0 {
+		upper = grid.boundaries(itemN - 1)
+	}
+	_, err = fmt.Fprintln(out, t.borderRule(widths, border, upper, nil, allTrue(colN)))
+	return err
+}
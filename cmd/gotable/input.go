@@ -0,0 +1,254 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	table "github.com/ilius/go-table"
+)
+
+// dataset is the parsed input: column names and rows of values,
+// values are strings, or JSON values for JSON input. alignments are set
+// by readers of aligned text tables, and types of string columns are
+// set by inferTypes
+type dataset struct {
+	columns    []string
+	rows       [][]any
+	alignments []table.Alignment
+	types      []*table.InferredType
+}
+
+const (
+	inputAuto     = "auto"
+	inputCSV      = "csv"
+	inputTSV      = "tsv"
+	inputJSON     = "json"
+	inputNDJSON   = "ndjson"
+	inputSpace    = "space"
+	inputMarkdown = "markdown"
+	inputBox      = "box"
+)
+
+// detectInputFormat guesses the input format from data
+func detectInputFormat(data []byte) string {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return inputSpace
+	}
+	switch trimmed[0] {
+	case '[':
+		return inputJSON
+	case '{':
+		if json.Valid(trimmed) {
+			return inputJSON
+		}
+		return inputNDJSON
+	}
+	firstLine, rest, _ := strings.Cut(string(trimmed), "\n")
+	secondLine, _, _ := strings.Cut(rest, "\n")
+	secondLine = strings.TrimSpace(secondLine)
+	first, _ := utf8.DecodeRuneInString(firstLine)
+	switch {
+	case strings.HasPrefix(firstLine, "|") && strings.HasPrefix(secondLine, "|") &&
+		strings.Trim(secondLine, "|:- ") == "":
+		return inputMarkdown
+	case strings.ContainsRune("+┌╔┏", first),
+		strings.Contains(secondLine, "-+-") && strings.Trim(secondLine, "-+") == "":
+		return inputBox
+
+	case strings.Contains(firstLine, "\t"):
+		return inputTSV
+	case strings.Contains(firstLine, ","):
+		return inputCSV
+	}
+	return inputSpace
+}
+
+func readInput(reader io.Reader, format string) (*dataset, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if format == inputAuto {
+		format = detectInputFormat(data)
+	}
+	switch format {
+	case inputCSV:
+		return readDelimited(data, ',')
+	case inputTSV:
+		return readDelimited(data, '\t')
+	case inputJSON:
+		return readJSON(data)
+	case inputNDJSON:
+		return readNDJSON(data)
+	case inputSpace:
+		return readText(table.ParseAligned(bytes.NewReader(data)))
+	case inputMarkdown:
+		return readText(table.ParseMarkdown(bytes.NewReader(data)))
+	case inputBox:
+		return readText(table.ParseBoxed(bytes.NewReader(data)))
+	}
+	return nil, fmt.Errorf("unknown input format %#v", format)
+}
+
+func stringRow(fields []string) []any {
+	row := make([]any, len(fields))
+	for i, field := range fields {
+		row[i] = field
+	}
+	return row
+}
+
+func readDelimited(data []byte, comma rune) (*dataset, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = comma
+	reader.FieldsPerRecord = -1
+	if comma == '\t' {
+		reader.LazyQuotes = true
+	}
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	ds := &dataset{}
+	if len(records) == 0 {
+		return ds, nil
+	}
+	ds.columns = records[0]
+	for _, record := range records[1:] {
+		row := stringRow(record)
+		for len(row) < len(ds.columns) {
+			row = append(row, "")
+		}
+		ds.rows = append(ds.rows, row[:len(ds.columns)])
+	}
+	return ds, nil
+}
+
+// readText converts a table read by one of the table parsers
+func readText(parsed *table.TextTable, err error) (*dataset, error) {
+	if err != nil {
+		return nil, err
+	}
+	ds := &dataset{}
+	for _, col := range parsed.Columns {
+		ds.columns = append(ds.columns, col.Name)
+		ds.alignments = append(ds.alignments, col.Alignment)
+	}
+	for _, row := range parsed.Rows {
+		ds.rows = append(ds.rows, stringRow(row))
+	}
+	return ds, nil
+}
+
+// jsonObjects builds a dataset from decoded objects, columns are the union
+// of keys in first-seen order (objects keys are in input order)
+func jsonObjects(objects []orderedObject) *dataset {
+	ds := &dataset{}
+	index := map[string]int{}
+	for _, obj := range objects {
+		for _, key := range obj.keys {
+			if _, ok := index[key]; !ok {
+				index[key] = len(ds.columns)
+				ds.columns = append(ds.columns, key)
+			}
+		}
+	}
+	for _, obj := range objects {
+		row := make([]any, len(ds.columns))
+		for i, key := range obj.keys {
+			row[index[key]] = obj.values[i]
+		}
+		ds.rows = append(ds.rows, row)
+	}
+	return ds
+}
+
+// orderedObject is a JSON object with keys in input order
+type orderedObject struct {
+	keys   []string
+	values []any
+}
+
+func (obj *orderedObject) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != json.Delim('{') {
+		return fmt.Errorf("expected JSON object, got %v", tok)
+	}
+	for dec.More() {
+		keyTok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var value any
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		obj.keys = append(obj.keys, keyTok.(string))
+		obj.values = append(obj.values, jsonNumber(value))
+	}
+	return nil
+}
+
+// jsonNumber converts a json.Number into int64 or float64
+func jsonNumber(value any) any {
+	num, ok := value.(json.Number)
+	if !ok {
+		return value
+	}
+	if n, err := num.Int64(); err == nil {
+		return n
+	}
+	if f, err := num.Float64(); err == nil {
+		return f
+	}
+	return num.String()
+}
+
+func readJSON(data []byte) (*dataset, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		obj := orderedObject{}
+		if err := json.Unmarshal(trimmed, &obj); err != nil {
+			return nil, err
+		}
+		return jsonObjects([]orderedObject{obj}), nil
+	}
+	objects := []orderedObject{}
+	if err := json.Unmarshal(trimmed, &objects); err != nil {
+		return nil, err
+	}
+	return jsonObjects(objects), nil
+}
+
+func readNDJSON(data []byte) (*dataset, error) {
+	objects := []orderedObject{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, 16*1024*1024)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		obj := orderedObject{}
+		if err := json.Unmarshal(line, &obj); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		objects = append(objects, obj)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return jsonObjects(objects), nil
+}
@@ -0,0 +1,460 @@
+// gotable reads a table from a file or stdin (CSV, TSV, JSON, NDJSON or
+// whitespace-separated) and renders it in one of the output formats
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	table "github.com/ilius/go-table"
+	"github.com/ilius/go-table/runewidth"
+)
+
+const usage = `usage: gotable [options] [FILE]
+
+Reads a table from FILE (or stdin) and renders it.
+
+input formats:  auto, csv, tsv, json, ndjson, space (aligned), markdown, box
+output formats: plain, bordered, markdown, html, json, ndjson, csv, tsv,
+                yaml, toml, xml, latex, rst, rst-simple, asciidoc, org,
+                mediawiki, jira, confluence, sql
+
+options:
+`
+
+// stringList is a flag.Value that can be given multiple times
+type stringList []string
+
+func (list *stringList) String() string {
+	return strings.Join(*list, ", ")
+}
+
+func (list *stringList) Set(value string) error {
+	*list = append(*list, value)
+	return nil
+}
+
+type options struct {
+	input    string
+	output   string
+	columns  string
+	sort     string
+	where    stringList
+	align    string
+	types    string
+	maxWidth int
+	merge    string
+	compact  bool
+	sep      string
+	border   string
+	color    string
+}
+
+func (opts *options) register(flags *flag.FlagSet) {
+	flags.StringVar(&opts.input, "input", "auto", "input format")
+	flags.StringVar(&opts.output, "output", "plain", "output format")
+	flags.StringVar(&opts.columns, "columns", "", "comma-separated columns to show, in order")
+	flags.StringVar(&opts.sort, "sort", "", "comma-separated columns to sort by, prefix with - for descending")
+	flags.Var(&opts.where, "where", "filter rows by `expr` like col=value, col!=value, col>n, col<=n or col~regexp, can be repeated")
+	flags.StringVar(&opts.align, "align", "", "comma-separated column alignments like name:left,size:right,id:center")
+	flags.StringVar(&opts.types, "types", "", "comma-separated column types like size:bytes,mtime:time:2006-01-02, instead of inferred types.\n"+
+		"Types are string, int, float, bool, time (with optional layout), duration and bytes")
+	flags.IntVar(&opts.maxWidth, "max-width", 0, "maximum line width, default is terminal width for --merge and no limit otherwise")
+	flags.StringVar(&opts.merge, "merge", "", "merge rows to fit the width: horizontal or vertical")
+	flags.BoolVar(&opts.compact, "compact", false, "compact column widths with --merge")
+	flags.StringVar(&opts.sep, "sep", "  ", "column separator of plain output")
+	flags.StringVar(&opts.border, "border", "light", "border of bordered output: light or ascii")
+	flags.StringVar(&opts.color, "color", "auto", "colors and hyperlinks: auto, always or never")
+}
+
+func main() {
+	err := run(os.Args[1:], os.Stdin, os.Stdout)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "gotable:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	opts := &options{}
+	flags := flag.NewFlagSet("gotable", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), usage)
+		flags.PrintDefaults()
+	}
+	opts.register(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	input := stdin
+	switch flags.NArg() {
+	case 0:
+	case 1:
+		if path := flags.Arg(0); path != "-" {
+			file, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			input = file
+		}
+	default:
+		return fmt.Errorf("too many arguments")
+	}
+	ds, err := readInput(input, opts.input)
+	if err != nil {
+		return err
+	}
+	inferOpts, err := parseTypes(opts.types)
+	if err != nil {
+		return err
+	}
+	if err := ds.inferTypes(inferOpts); err != nil {
+		return err
+	}
+	conds := make([]*condition, len(opts.where))
+	for i, expr := range opts.where {
+		conds[i], err = parseCondition(expr, ds)
+		if err != nil {
+			return err
+		}
+	}
+	ds.filter(conds)
+	if opts.sort != "" {
+		if err := ds.sortRows(opts.sort); err != nil {
+			return err
+		}
+	}
+	t, err := newTable(ds, opts)
+	if err != nil {
+		return err
+	}
+	items := make([]any, len(ds.rows))
+	for i, row := range ds.rows {
+		items[i] = row
+	}
+	return write(stdout, t, items, opts)
+}
+
+func parseAlignment(name string) (table.Alignment, error) {
+	switch name {
+	case "left":
+		return table.AlignmentLeft, nil
+	case "right":
+		return table.AlignmentRight, nil
+	case "center":
+		return table.AlignmentCenter, nil
+	}
+	return nil, fmt.Errorf("invalid alignment %#v", name)
+}
+
+// newTable creates the table of selected columns, numeric columns are
+// right-aligned and others keep alignment of aligned input, unless set by --align
+func newTable(ds *dataset, opts *options) (*table.Table, error) {
+	indexes := []int{}
+	if opts.columns == "" {
+		for i := range ds.columns {
+			indexes = append(indexes, i)
+		}
+	} else {
+		for _, name := range strings.Split(opts.columns, ",") {
+			name = strings.TrimSpace(name)
+			index := ds.columnIndex(name)
+			if index == -1 {
+				return nil, fmt.Errorf("unknown column %#v", name)
+			}
+			indexes = append(indexes, index)
+		}
+	}
+	spec := table.NewTableSpec()
+	for _, index := range indexes {
+		name := ds.columns[index]
+		if spec.HasColumn(name) {
+			continue
+		}
+		col := &table.Column{
+			Name:   name,
+			Title:  name,
+			Getter: &rowGetter{index: index, typ: ds.types[index]},
+		}
+		if typ := ds.types[index]; typ != nil {
+			col.Type = typ.Type
+		}
+		switch {
+		case ds.isNumeric(index):
+			col.Alignment = table.AlignmentRight
+		case ds.alignments != nil:
+			col.Alignment = ds.alignments[index]
+		}
+		spec.AddColumn(col)
+	}
+	if opts.align != "" {
+		for _, part := range strings.Split(opts.align, ",") {
+			name, alName, ok := strings.Cut(part, ":")
+			if !ok {
+				return nil, fmt.Errorf("invalid alignment %#v, must be column:alignment", part)
+			}
+			col := spec.ColumnByName[strings.TrimSpace(name)]
+			if col == nil {
+				return nil, fmt.Errorf("unknown column %#v", name)
+			}
+			al, err := parseAlignment(strings.TrimSpace(alName))
+			if err != nil {
+				return nil, err
+			}
+			col.Alignment = al
+		}
+	}
+	return table.NewTable(spec), nil
+}
+
+// setColor configures colors and hyperlinks for --color
+func setColor(t *table.Table, out io.Writer, mode string) error {
+	switch mode {
+	case "auto":
+		t.DetectOutput(out)
+	case "always":
+		t.SetColor(true)
+		t.SetHyperlinks(true)
+	case "never":
+		t.SetColor(false)
+		t.SetHyperlinks(false)
+		t.SetStripColors(true)
+	default:
+		return fmt.Errorf("invalid color mode %#v", mode)
+	}
+	return nil
+}
+
+// terminalWidth returns $COLUMNS, or 80
+func terminalWidth() int {
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	return 80
+}
+
+// limitWriter truncates lines to a maximum visual width
+type limitWriter struct {
+	out   io.Writer
+	width int
+}
+
+func (w *limitWriter) Write(p []byte) (int, error) {
+	lines := strings.SplitAfter(string(p), "\n")
+	for _, line := range lines {
+		text := strings.TrimSuffix(line, "\n")
+		text = table.TruncateWidth(text, uint16(w.width))
+		if strings.HasSuffix(line, "\n") {
+			text += "\n"
+		}
+		if _, err := io.WriteString(w.out, text); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// writeTerminal writes plain, bordered or merged output
+func writeTerminal(out io.Writer, t *table.Table, items []any, opts *options) error {
+	if err := setColor(t, out, opts.color); err != nil {
+		return err
+	}
+	widths := map[string]uint16{}
+	for _, col := range t.Columns {
+		widths[col.Name] = uint16(runewidth.StringWidth(col.Title))
+	}
+	t.UpdateWidth(widths)
+	formatted := make(table.FormattedItems, len(items))
+	for i, item := range items {
+		row, err := t.FormatItem(item)
+		if err != nil {
+			return err
+		}
+		formatted[i] = row
+	}
+	switch opts.merge {
+	case "":
+	case "horizontal", "vertical":
+		maxWidth := opts.maxWidth
+		if maxWidth <= 0 {
+			maxWidth = terminalWidth()
+		}
+		if opts.merge == "horizontal" {
+			t.MergeRowsHorizontal(out, formatted, maxWidth, opts.sep, opts.compact)
+		} else {
+			t.MergeRowsVertical(out, formatted, maxWidth, opts.sep, opts.compact)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid merge mode %#v", opts.merge)
+	}
+	if opts.maxWidth > 0 {
+		out = &limitWriter{out: out, width: opts.maxWidth}
+	}
+	if opts.output == "plain" {
+		return t.WritePlain(out, formatted, opts.sep)
+	}
+	var border *table.Border
+	switch opts.border {
+	case "light":
+		border = table.BorderLight
+	case "ascii":
+		border = table.BorderASCII
+	default:
+		return fmt.Errorf("invalid border %#v", opts.border)
+	}
+	return t.WriteBordered(out, formatted, border)
+}
+
+// cellValue returns the value of a cell for JSON output, nested
+// JSON values are kept as they are and durations are strings
+func cellValue(col *table.Column, item any) (any, error) {
+	if getter, ok := col.Getter.(*rowGetter); ok {
+		raw, err := getter.raw(item)
+		if err != nil {
+			return nil, err
+		}
+		switch raw.(type) {
+		case map[string]any, []any:
+			return raw, nil
+		}
+	}
+	value, err := col.Getter.Value(item)
+	if d, ok := value.(time.Duration); ok {
+		return d.String(), err
+	}
+	return value, err
+}
+
+// writeJSON writes items as a JSON array of objects, or as one object
+// per line if lines is true, keys are in column order
+func writeJSON(out io.Writer, t *table.Table, items []any, lines bool) error {
+	var sb strings.Builder
+	if !lines {
+		sb.WriteString("[")
+	}
+	for itemI, item := range items {
+		if !lines {
+			if itemI > 0 {
+				sb.WriteString(",")
+			}
+			sb.WriteString("\n  ")
+		}
+		sb.WriteString("{")
+		for colI, col := range t.Columns {
+			value, err := cellValue(col, item)
+			if err != nil {
+				return err
+			}
+			key, err := json.Marshal(col.Name)
+			if err != nil {
+				return err
+			}
+			valueJSON, err := json.Marshal(value)
+			if err != nil {
+				return err
+			}
+			if colI > 0 {
+				sb.WriteString(", ")
+			}
+			sb.Write(key)
+			sb.WriteString(": ")
+			sb.Write(valueJSON)
+		}
+		sb.WriteString("}")
+		if lines {
+			sb.WriteString("\n")
+		}
+	}
+	if !lines {
+		if len(items) > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("]\n")
+	}
+	_, err := io.WriteString(out, sb.String())
+	return err
+}
+
+func writeDelimited(out io.Writer, t *table.Table, items []any, comma rune) error {
+	w := csv.NewWriter(out)
+	w.Comma = comma
+	header := make([]string, t.ColumnCount())
+	for i, col := range t.Columns {
+		header[i] = col.Title
+	}
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	for _, item := range items {
+		record := make([]string, t.ColumnCount())
+		for i, col := range t.Columns {
+			str, err := col.Getter.ValueString(col.Name, item)
+			if err != nil {
+				return err
+			}
+			record[i] = str
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func write(out io.Writer, t *table.Table, items []any, opts *options) error {
+	switch opts.output {
+	case "plain", "bordered":
+		return writeTerminal(out, t, items, opts)
+	case "markdown":
+		return t.WriteMarkdown(out, items)
+	case "html":
+		return t.WriteHTML(out, items, nil)
+	case "json":
+		return writeJSON(out, t, items, false)
+	case "ndjson":
+		return writeJSON(out, t, items, true)
+	case "csv":
+		return writeDelimited(out, t, items, ',')
+	case "tsv":
+		return writeDelimited(out, t, items, '\t')
+	case "yaml":
+		return t.WriteYAML(out, items)
+	case "toml":
+		return t.WriteTOML(out, items, "rows")
+	case "xml":
+		return t.WriteXML(out, items, nil)
+	case "latex":
+		return t.WriteLaTeX(out, items, nil)
+	case "rst":
+		return t.WriteRSTGrid(out, items)
+	case "rst-simple":
+		return t.WriteRSTSimple(out, items)
+	case "asciidoc":
+		return t.WriteAsciiDoc(out, items)
+	case "org":
+		return t.WriteOrgMode(out, items)
+	case "mediawiki":
+		return t.WriteMediaWiki(out, items)
+	case "jira":
+		return t.WriteJira(out, items)
+	case "confluence":
+		return t.WriteConfluence(out, items)
+	case "sql":
+		return t.WriteSQL(out, items, &table.SQLOptions{TableName: "rows", CreateTable: true})
+	}
+	return fmt.Errorf("unknown output format %#v", opts.output)
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+var goldenTests = []struct {
+	name string
+	args []string
+}{
+	{"csv_plain", []string{"testdata/files.csv"}},
+	{"csv_bordered", []string{"-output", "bordered", "testdata/files.csv"}},
+	{"csv_bordered_ascii", []string{"-output", "bordered", "-border", "ascii", "testdata/files.csv"}},
+	{"csv_columns", []string{"--columns", "size,name", "testdata/files.csv"}},
+	{"csv_sort", []string{"--sort", "-size", "testdata/files.csv"}},
+	{"csv_sort_multi", []string{"--sort", "kind,name", "testdata/files.csv"}},
+	{"csv_where", []string{"--where", "size>100", "--where", "kind=file", "testdata/files.csv"}},
+	{"csv_where_regexp", []string{"--where", `name~\.(go|md)$`, "testdata/files.csv"}},
+	{"csv_align", []string{"--align", "name:right,kind:center", "testdata/files.csv"}},
+	{"csv_max_width", []string{"--max-width", "20", "testdata/files.csv"}},
+	{"csv_merge_horizontal", []string{"--merge", "horizontal", "--max-width", "60", "--columns", "name,size", "testdata/files.csv"}},
+	{"csv_merge_vertical", []string{"--merge", "vertical", "--max-width", "60", "--columns", "name,size", "testdata/files.csv"}},
+	{"csv_merge_compact", []string{"--merge", "horizontal", "--compact", "--max-width", "60", "--columns", "name,size", "testdata/files.csv"}},
+	{"csv_markdown", []string{"-output", "markdown", "testdata/files.csv"}},
+	{"csv_html", []string{"-output", "html", "testdata/files.csv"}},
+	{"csv_csv", []string{"-output", "csv", "testdata/files.csv"}},
+	{"tsv_plain", []string{"testdata/files.tsv"}},
+	{"json_plain", []string{"testdata/people.json"}},
+	{"json_json", []string{"-output", "json", "testdata/people.json"}},
+	{"json_yaml", []string{"-output", "yaml", "testdata/people.json"}},
+	{"ndjson_plain", []string{"testdata/events.ndjson"}},
+	{"ndjson_markdown", []string{"-output", "markdown", "testdata/events.ndjson"}},
+	{"ndjson_ndjson", []string{"-output", "ndjson", "--where", "level!=info", "testdata/events.ndjson"}},
+	{"space_plain", []string{"testdata/ps.txt"}},
+	{"space_tsv", []string{"-output", "tsv", "testdata/ps.txt"}},
+	{"space_sort_duration", []string{"--sort", "-TIME", "testdata/ps_time.txt"}},
+	{"csv_types_json", []string{"-output", "json", "testdata/sizes.csv"}},
+	{"csv_types_sort", []string{"--sort", "size", "--where", "size>=1K", "testdata/sizes.csv"}},
+	{"csv_types_override", []string{"-output", "sql", "--types", "size:string,date:time:2006-01-02", "testdata/sizes.csv"}},
+	{"csv_types_time", []string{"-output", "sql", "--types", "date:time", "testdata/sizes.csv"}},
+	{"markdown_bordered", []string{"-output", "bordered", "testdata/files.md"}},
+	{"box_markdown", []string{"-output", "markdown", "testdata/psql.txt"}},
+	{"bordered_csv", []string{"-output", "csv", "testdata/csv_bordered.golden"}},
+}
+
+func TestGolden(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	for _, tc := range goldenTests {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			out := &bytes.Buffer{}
+			err := run(tc.args, strings.NewReader(""), out)
+			is.NotErr(err)
+			goldenPath := filepath.Join("testdata", tc.name+".golden")
+			if *update {
+				err := os.WriteFile(goldenPath, out.Bytes(), 0o644)
+				is.NotErr(err)
+				return
+			}
+			expected, err := os.ReadFile(goldenPath)
+			is.NotErr(err)
+			is.Equal(out.String(), string(expected))
+		})
+	}
+}
+
+func TestStdin(t *testing.T) {
+	is := is.New(t)
+	out := &bytes.Buffer{}
+	err := run([]string{"-output", "csv"}, strings.NewReader("a b\n1 2\n"), out)
+	is.NotErr(err)
+	is.Equal(out.String(), "a,b\n1,2\n")
+}
+
+func TestErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"--columns", "nope", "testdata/files.csv"},
+		{"--sort", "nope", "testdata/files.csv"},
+		{"--where", "size", "testdata/files.csv"},
+		{"--where", "nope=1", "testdata/files.csv"},
+		{"--align", "name:middle", "testdata/files.csv"},
+		{"--merge", "diagonal", "testdata/files.csv"},
+		{"-output", "nope", "testdata/files.csv"},
+		{"-input", "json", "testdata/files.csv"},
+		{"--types", "size:int8", "testdata/files.csv"},
+		{"--types", "nope:int", "testdata/files.csv"},
+		{"--types", "size", "testdata/files.csv"},
+	} {
+		is := is.New(t).Msg("args=%v", args)
+		err := run(args, strings.NewReader(""), &bytes.Buffer{})
+		is.Err(err)
+	}
+}
+
+func TestDetectInputFormat(t *testing.T) {
+	is := is.New(t)
+	is.Equal(detectInputFormat([]byte(" [{}]")), inputJSON)
+	is.Equal(detectInputFormat([]byte(`{"a": 1}`)), inputJSON)
+	is.Equal(detectInputFormat([]byte("{\"a\": 1}\n{\"a\": 2}\n")), inputNDJSON)
+	is.Equal(detectInputFormat([]byte("a\tb\n1\t2\n")), inputTSV)
+	is.Equal(detectInputFormat([]byte("a,b\n1,2\n")), inputCSV)
+	is.Equal(detectInputFormat([]byte("a b\n1 2\n")), inputSpace)
+	is.Equal(detectInputFormat([]byte("| a | b |\n|---|--:|\n")), inputMarkdown)
+	is.Equal(detectInputFormat([]byte("+---+\n| a |\n")), inputBox)
+	is.Equal(detectInputFormat([]byte(" a | b\n---+---\n 1 | 2\n")), inputBox)
+	is.Equal(detectInputFormat([]byte("┌───┐\n│ a │\n└───┘\n")), inputBox)
+	is.Equal(detectInputFormat([]byte("╔═══╗\n║ a ║\n╚═══╝\n")), inputBox)
+}
@@ -0,0 +1,386 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	table "github.com/ilius/go-table"
+)
+
+// cellString returns the text of a cell value, nested JSON values are
+// written as compact JSON
+func cellString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case map[string]any, []any:
+		jsonBytes, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(jsonBytes)
+	}
+	return fmt.Sprint(value)
+}
+
+// rowGetter is a table.Getter for column index of dataset rows,
+// string cells are parsed as typ if it is set
+type rowGetter struct {
+	index int
+	typ   *table.InferredType
+}
+
+// raw returns the cell value as it was read
+func (g *rowGetter) raw(item any) (any, error) {
+	row, ok := item.([]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid item type %T", item)
+	}
+	if g.index >= len(row) {
+		return nil, nil
+	}
+	return row[g.index], nil
+}
+
+// Value returns the cell value, nested JSON values are returned as
+// compact JSON strings
+func (g *rowGetter) Value(item any) (any, error) {
+	value, err := g.raw(item)
+	if err != nil {
+		return nil, err
+	}
+	switch v := value.(type) {
+	case map[string]any, []any:
+		return cellString(value), nil
+	case string:
+		if g.typ == nil {
+			return v, nil
+		}
+		parsed, err := g.typ.Parse(v)
+		if err != nil {
+			// keep invalid values as they are
+			return v, nil
+		}
+		return parsed, nil
+	}
+	return value, nil
+}
+
+func (g *rowGetter) ValueString(colName string, item any) (string, error) {
+	value, err := g.raw(item)
+	if err != nil {
+		return "", err
+	}
+	return cellString(value), nil
+}
+
+func (g *rowGetter) Format(item any, value any) (string, error) {
+	if g.typ != nil {
+		// keep the original text of parsed cells
+		return g.ValueString("", item)
+	}
+	return cellString(value), nil
+}
+
+func parseNumber(str string) (float64, bool) {
+	if str == "" {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(str, 64)
+	return f, err == nil
+}
+
+// compareCells compares two cells numerically if both are numbers,
+// and as strings otherwise
+func compareCells(a string, b string) int {
+	fa, okA := parseNumber(a)
+	fb, okB := parseNumber(b)
+	if okA && okB {
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(a, b)
+}
+
+// compareTyped compares two cells as values of typ if both are valid,
+// or with compareCells
+func compareTyped(typ *table.InferredType, a string, b string) int {
+	if typ != nil {
+		va, errA := typ.Parse(a)
+		vb, errB := typ.Parse(b)
+		if errA == nil && errB == nil {
+			return table.CompareValues(va, vb)
+		}
+	}
+	return compareCells(a, b)
+}
+
+// condition is a parsed --where expression like "size>100"
+type condition struct {
+	colIndex int
+	op       string
+	value    string
+	re       *regexp.Regexp
+	typ      *table.InferredType
+}
+
+// whereOps are checked in order, so two-character operators come first
+var whereOps = []string{"!=", ">=", "<=", "!~", "=", ">", "<", "~"}
+
+func parseCondition(expr string, ds *dataset) (*condition, error) {
+	opIndex := -1
+	op := ""
+	for _, candidate := range whereOps {
+		i := strings.Index(expr, candidate)
+		if i < 1 {
+			continue
+		}
+		if opIndex == -1 || i < opIndex || i == opIndex && len(candidate) > len(op) {
+			opIndex = i
+			op = candidate
+		}
+	}
+	if opIndex == -1 {
+		return nil, fmt.Errorf("invalid where expression %#v", expr)
+	}
+	colName := strings.TrimSpace(expr[:opIndex])
+	colIndex := ds.columnIndex(colName)
+	if colIndex == -1 {
+		return nil, fmt.Errorf("invalid where expression %#v: unknown column %#v", expr, colName)
+	}
+	cond := &condition{
+		colIndex: colIndex,
+		op:       op,
+		value:    strings.TrimSpace(expr[opIndex+len(op):]),
+		typ:      ds.types[colIndex],
+	}
+	if op == "~" || op == "!~" {
+		re, err := regexp.Compile(cond.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid where expression %#v: %w", expr, err)
+		}
+		cond.re = re
+	}
+	return cond, nil
+}
+
+func (cond *condition) match(row []any) bool {
+	cell := cellString(row[cond.colIndex])
+	switch cond.op {
+	case "~":
+		return cond.re.MatchString(cell)
+	case "!~":
+		return !cond.re.MatchString(cell)
+	}
+	cmp := compareTyped(cond.typ, cell, cond.value)
+	switch cond.op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	}
+	return false
+}
+
+func (ds *dataset) columnIndex(colName string) int {
+	for i, name := range ds.columns {
+		if name == colName {
+			return i
+		}
+	}
+	return -1
+}
+
+// filter keeps the rows matching all conditions
+func (ds *dataset) filter(conds []*condition) {
+	if len(conds) == 0 {
+		return
+	}
+	rows := ds.rows[:0]
+rowLoop:
+	for _, row := range ds.rows {
+		for _, cond := range conds {
+			if !cond.match(row) {
+				continue rowLoop
+			}
+		}
+		rows = append(rows, row)
+	}
+	ds.rows = rows
+}
+
+// sortRows sorts rows by comma-separated column names, each can be
+// prefixed by "-" for descending order
+func (ds *dataset) sortRows(keys string) error {
+	type sortKey struct {
+		index      int
+		descending bool
+	}
+	sortKeys := []sortKey{}
+	for _, name := range strings.Split(keys, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		key := sortKey{}
+		if strings.HasPrefix(name, "-") {
+			key.descending = true
+			name = name[1:]
+		}
+		key.index = ds.columnIndex(name)
+		if key.index == -1 {
+			return fmt.Errorf("invalid sort column %#v", name)
+		}
+		sortKeys = append(sortKeys, key)
+	}
+	sort.SliceStable(ds.rows, func(i, j int) bool {
+		for _, key := range sortKeys {
+			cmp := compareTyped(
+				ds.types[key.index],
+				cellString(ds.rows[i][key.index]),
+				cellString(ds.rows[j][key.index]),
+			)
+			if cmp == 0 {
+				continue
+			}
+			if key.descending {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return false
+	})
+	return nil
+}
+
+// typeNames are type names of --types
+var typeNames = map[string]reflect.Type{
+	"string":   reflect.TypeOf(""),
+	"int":      reflect.TypeOf(int64(0)),
+	"float":    reflect.TypeOf(float64(0)),
+	"bool":     reflect.TypeOf(false),
+	"time":     reflect.TypeOf(time.Time{}),
+	"duration": reflect.TypeOf(time.Duration(0)),
+	"bytes":    reflect.TypeOf(table.ByteSize(0)),
+}
+
+// parseTypes parses --types like "size:bytes,mtime:time:2006-01-02"
+// into types and time layouts by column name
+func parseTypes(arg string) (*table.InferOptions, error) {
+	opts := &table.InferOptions{
+		Types:       map[string]reflect.Type{},
+		TimeLayouts: map[string]string{},
+	}
+	if arg == "" {
+		return opts, nil
+	}
+	for _, part := range strings.Split(arg, ",") {
+		name, typeName, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid type %#v, must be column:type", part)
+		}
+		typeName, layout, _ := strings.Cut(typeName, ":")
+		typ, ok := typeNames[typeName]
+		if !ok {
+			return nil, fmt.Errorf("invalid type %#v", typeName)
+		}
+		name = strings.TrimSpace(name)
+		opts.Types[name] = typ
+		if layout != "" {
+			opts.TimeLayouts[name] = layout
+		}
+	}
+	return opts, nil
+}
+
+// inferTypes infers types of columns with only string values, or sets
+// types given by opts, with table.TextTable.InferTypes
+func (ds *dataset) inferTypes(opts *table.InferOptions) error {
+	for name := range opts.Types {
+		if ds.columnIndex(name) == -1 {
+			return fmt.Errorf("unknown column %#v", name)
+		}
+	}
+	ds.types = make([]*table.InferredType, len(ds.columns))
+	for colIndex, name := range ds.columns {
+		if _, override := opts.Types[name]; !override && !ds.isText(colIndex) {
+			continue
+		}
+		// one column at a time, since column names may be repeated
+		tt := &table.TextTable{
+			Columns: []*table.Column{{Name: name}},
+			Rows:    make([][]string, len(ds.rows)),
+		}
+		for rowIndex, row := range ds.rows {
+			tt.Rows[rowIndex] = []string{cellString(row[colIndex])}
+		}
+		inferred := tt.InferTypes(opts)[name]
+		if inferred.Type == typeNames["string"] {
+			continue
+		}
+		ds.types[colIndex] = inferred
+	}
+	return nil
+}
+
+// isText returns true if all cells of column are strings (or nil)
+func (ds *dataset) isText(colIndex int) bool {
+	for _, row := range ds.rows {
+		switch row[colIndex].(type) {
+		case nil, string:
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// isNumeric returns true if all non-empty cells of column are numbers
+func (ds *dataset) isNumeric(colIndex int) bool {
+	if typ := ds.types[colIndex]; typ != nil {
+		return typ.IsNumeric()
+	}
+	found := false
+	for _, row := range ds.rows {
+		switch v := row[colIndex].(type) {
+		case nil:
+			continue
+		case int64, float64:
+			found = true
+			continue
+		case string:
+			if v == "" {
+				continue
+			}
+			if _, ok := parseNumber(v); ok {
+				found = true
+				continue
+			}
+		}
+		return false
+	}
+	return found
+}
@@ -0,0 +1,43 @@
+package table
+
+import "fmt"
+
+// testGetter is a Getter of value index of []any items
+type testGetter struct {
+	index int
+}
+
+func (g testGetter) Value(item any) (any, error) {
+	return item.([]any)[g.index], nil
+}
+
+func (g testGetter) ValueString(colName string, item any) (string, error) {
+	return fmt.Sprint(item.([]any)[g.index]), nil
+}
+
+func (g testGetter) Format(item any, value any) (string, error) {
+	return fmt.Sprint(value), nil
+}
+
+// newTestTable returns a table with one testGetter column per name
+func newTestTable(names ...string) *Table {
+	spec := NewTableSpec()
+	for i, name := range names {
+		spec.AddColumn(&Column{
+			Name:   name,
+			Title:  name,
+			Getter: testGetter{index: i},
+		})
+	}
+	return NewTable(spec)
+}
+
+// newTestTableItems returns a table of newTestTable(names...) and rows
+// as items
+func newTestTableItems(names []string, rows ...[]any) (*Table, []any) {
+	items := make([]any, len(rows))
+	for i, row := range rows {
+		items[i] = row
+	}
+	return newTestTable(names...), items
+}
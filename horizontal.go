@@ -35,6 +35,7 @@ func (t *Table) MergeRowsHorizontal(
 			}
 		}
 	}
+	styledSep := t.styledSeparator(sep)
 	itemN := items.Len()
 	lineCount := (itemN-1)/groupCount + 1
 	for lineI := 0; lineI < lineCount; lineI++ {
@@ -59,7 +60,7 @@ func (t *Table) MergeRowsHorizontal(
 			}
 			line = append(line, strings.Join(cell, innerSep))
 		}
-		_, err := fmt.Fprintln(out, strings.Join(line, sep))
+		_, err := fmt.Fprintln(out, strings.Join(line, styledSep))
 		if err != nil {
 			panic(err)
 		}
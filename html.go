@@ -0,0 +1,276 @@
+package table
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// HTMLOptions are options of WriteHTML
+type HTMLOptions struct {
+	// Class is the class attribute of <table>
+	Class string
+	// Title is the page title if Standalone is true
+	Title string
+	// Standalone writes a complete page with embedded CSS
+	Standalone bool
+}
+
+const htmlCSS = `table.go-table {
+	border-collapse: collapse;
+	font-family: monospace;
+}
+table.go-table th, table.go-table td {
+	border: 1px solid #ccc;
+	padding: 2px 8px;
+	white-space: pre;
+}
+table.go-table th {
+	background: #eee;
+}
+.align-left {
+	text-align: left;
+}
+.align-right {
+	text-align: right;
+}
+.align-center {
+	text-align: center;
+}
+`
+
+func htmlColor(c Color) string {
+	r, g, b := c.rgb()
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
+
+// htmlStyleAttr returns CSS declarations of style
+func htmlStyleAttr(style Style) string {
+	fg, bg := "", ""
+	if !style.Fg.IsDefault() {
+		fg = htmlColor(style.Fg)
+	}
+	if !style.Bg.IsDefault() {
+		bg = htmlColor(style.Bg)
+	}
+	if style.Inverse {
+		fg, bg = bg, fg
+		if fg == "" {
+			fg = "#ffffff"
+		}
+		if bg == "" {
+			bg = "#000000"
+		}
+	}
+	decls := []string{}
+	if fg != "" {
+		decls = append(decls, "color: "+fg)
+	}
+	if bg != "" {
+		decls = append(decls, "background-color: "+bg)
+	}
+	if style.Bold {
+		decls = append(decls, "font-weight: bold")
+	}
+	if style.Underline {
+		decls = append(decls, "text-decoration: underline")
+	}
+	return strings.Join(decls, "; ")
+}
+
+// ansiToHTML converts a formatted cell to HTML: text is escaped, SGR
+// sequences become <span style> elements and OSC 8 hyperlinks become
+// <a> elements. Other control sequences are dropped
+func ansiToHTML(str string) string {
+	var sb strings.Builder
+	style := Style{}
+	spanOpen := false
+	linkOpen := false
+	closeSpan := func() {
+		if spanOpen {
+			sb.WriteString("</span>")
+			spanOpen = false
+		}
+	}
+	ForEachToken(str, func(tok Token) {
+		switch tok.Kind {
+		case TokenText:
+			if !spanOpen && !style.IsZero() {
+				sb.WriteString(`<span style="` + htmlStyleAttr(style) + `">`)
+				spanOpen = true
+			}
+			sb.WriteString(html.EscapeString(tok.Text))
+		case TokenCSI:
+			params, ok := sgrTokenParams(tok)
+			if !ok {
+				return
+			}
+			newStyle := applySGR(style, params)
+			if newStyle != style {
+				closeSpan()
+				style = newStyle
+			}
+		case TokenOSC:
+			url, ok := osc8Params(tok.Text)
+			if !ok {
+				return
+			}
+			closeSpan()
+			if linkOpen {
+				sb.WriteString("</a>")
+				linkOpen = false
+			}
+			if url != "" {
+				sb.WriteString(`<a href="` + html.EscapeString(url) + `">`)
+				linkOpen = true
+			}
+		}
+	})
+	closeSpan()
+	if linkOpen {
+		sb.WriteString("</a>")
+	}
+	return sb.String()
+}
+
+func htmlAlignClass(al Alignment) string {
+	name := alignmentName(al)
+	if name == "" {
+		return ""
+	}
+	return ` class="align-` + name + `"`
+}
+
+// htmlSpanAttrs returns rowspan and colspan attributes of a cell
+func htmlSpanAttrs(rows int, cols int) string {
+	attrs := ""
+	if rows > 1 {
+		attrs += ` rowspan="` + strconv.Itoa(rows) + `"`
+	}
+	if cols > 1 {
+		attrs += ` colspan="` + strconv.Itoa(cols) + `"`
+	}
+	return attrs
+}
+
+// writeHTMLHeader writes rows of HeaderRows and column titles, titles of
+// columns without a group in the last header rows span those rows
+func (t *Table) writeHTMLHeader(sb *strings.Builder, levels [][]int) {
+	levelN := len(levels)
+	// titleLevel is the first row of the title of each column
+	titleLevel := make([]int, t.ColumnCount())
+	for colI := range titleLevel {
+		titleLevel[colI] = levelN
+		for titleLevel[colI] > 0 && levels[titleLevel[colI]-1][colI] < 0 {
+			titleLevel[colI]--
+		}
+	}
+	writeTitle := func(colI int, rows int) {
+		col := t.Columns[colI]
+		attrs := htmlSpanAttrs(rows, 1) + htmlAlignClass(col.Alignment)
+		if col.ShortTitle != "" {
+			attrs += ` abbr="` + html.EscapeString(col.ShortTitle) + `"`
+			attrs += ` title="` + html.EscapeString(col.Title) + `"`
+		}
+		sb.WriteString("<th" + attrs + ">" + html.EscapeString(col.Title) + "</th>\n")
+	}
+	for levelI, level := range levels {
+		sb.WriteString("<tr>\n")
+		for colI := 0; colI < len(level); colI++ {
+			groupI := level[colI]
+			if groupI >= 0 {
+				group := t.HeaderRows[levelI][groupI]
+				sb.WriteString("<th" + htmlSpanAttrs(1, len(group.Columns)) + ">" + html.EscapeString(group.Title) + "</th>\n")
+				colI += len(group.Columns) - 1
+				continue
+			}
+			switch {
+			case levelI == titleLevel[colI]:
+				writeTitle(colI, levelN-levelI+1)
+			case levelI < titleLevel[colI]:
+				sb.WriteString("<th></th>\n")
+			}
+		}
+		sb.WriteString("</tr>\n")
+	}
+	sb.WriteString("<tr>\n")
+	for colI := range t.Columns {
+		if titleLevel[colI] == levelN {
+			writeTitle(colI, 1)
+		}
+	}
+	sb.WriteString("</tr>\n")
+}
+
+// WriteHTML writes items as a <table>, colors of formatted cells
+// are converted from ANSI SGR sequences to <span style> elements.
+// HeaderRows and cell spans are written with colspan and rowspan
+func (t *Table) WriteHTML(out io.Writer, items []any, opts *HTMLOptions) error {
+	if opts == nil {
+		opts = &HTMLOptions{}
+	}
+	var sb strings.Builder
+	class := opts.Class
+	if opts.Standalone {
+		title := opts.Title
+		sb.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+		sb.WriteString("<title>" + html.EscapeString(title) + "</title>\n")
+		sb.WriteString("<style>\n" + htmlCSS + "</style>\n</head>\n<body>\n")
+		if class == "" {
+			class = "go-table"
+		}
+	}
+	if class != "" {
+		sb.WriteString(`<table class="` + html.EscapeString(class) + "\">\n")
+	} else {
+		sb.WriteString("<table>\n")
+	}
+	levels, err := t.headerGroupIndex()
+	if err != nil {
+		return err
+	}
+	grid, err := t.spanGrid(len(items))
+	if err != nil {
+		return err
+	}
+	sb.WriteString("<thead>\n")
+	t.writeHTMLHeader(&sb, levels)
+	sb.WriteString("</thead>\n<tbody>\n")
+	for itemI, item := range items {
+		sb.WriteString("<tr>\n")
+		for colI, col := range t.Columns {
+			pos := cellPos{row: itemI, col: colI}
+			if grid.owner[itemI][colI] != pos {
+				continue
+			}
+			value, err := col.Getter.Value(item)
+			if err != nil {
+				return err
+			}
+			formatted, err := col.Getter.Format(item, value)
+			if err != nil {
+				return err
+			}
+			link, err := columnLink(col, item)
+			if err != nil {
+				return err
+			}
+			cell := ansiToHTML(formatted)
+			// a cell with an OSC 8 hyperlink is already an <a> element
+			if url, _ := linkTarget(formatted); link != "" && url == "" {
+				cell = `<a href="` + html.EscapeString(link) + `">` + cell + "</a>"
+			}
+			rows, cols := grid.span(pos)
+			sb.WriteString("<td" + htmlSpanAttrs(rows, cols) + htmlAlignClass(col.Alignment) + ">" + cell + "</td>\n")
+		}
+		sb.WriteString("</tr>\n")
+	}
+	sb.WriteString("</tbody>\n</table>\n")
+	if opts.Standalone {
+		sb.WriteString("</body>\n</html>\n")
+	}
+	_, err = io.WriteString(out, sb.String())
+	return err
+}
@@ -0,0 +1,66 @@
+package table
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestAnsiToHTML(t *testing.T) {
+	is := is.New(t)
+	is.Equal(ansiToHTML("a<b"), "a&lt;b")
+	is.Equal(
+		ansiToHTML("\x1b[1;31mred\x1b[0m & \x1b[38;2;0;128;255mblue"+reset),
+		`<span style="color: #cd0000; font-weight: bold">red</span> &amp; `+
+			`<span style="color: #0080ff">blue</span>`,
+	)
+	is.Equal(
+		ansiToHTML(Hyperlink("https://example.com/?a=1&b=2", "link")),
+		`<a href="https://example.com/?a=1&amp;b=2">link</a>`,
+	)
+}
+
+func TestWriteHTML(t *testing.T) {
+	is := is.New(t)
+	tab := newTestTable("name", "size")
+	tab.Columns[1].Alignment = AlignmentRight
+	tab.Columns[1].ShortTitle = "sz"
+	out := &strings.Builder{}
+	is.NotErr(tab.WriteHTML(out, []any{
+		[]any{"<a>", 12},
+		[]any{Fg(2) + "b" + reset, 1024},
+	}, nil))
+	is.Equal(out.String(), `<table>
+<thead>
+<tr>
+<th>name</th>
+<th class="align-right" abbr="sz" title="size">size</th>
+</tr>
+</thead>
+<tbody>
+<tr>
+<td>&lt;a&gt;</td>
+<td class="align-right">12</td>
+</tr>
+<tr>
+<td><span style="color: #00cd00">b</span></td>
+<td class="align-right">1024</td>
+</tr>
+</tbody>
+</table>
+`)
+}
+
+func TestWriteHTMLLinks(t *testing.T) {
+	is := is.New(t)
+	tab := newTestTable("name")
+	tab.Columns[0].Getter = testLinkGetter{testGetter{index: 0}}
+	out := &strings.Builder{}
+	is.NotErr(tab.WriteHTML(out, []any{
+		[]any{"a"},
+		[]any{Hyperlink("https://example.org/b", "b")},
+	}, nil))
+	is.True(strings.Contains(out.String(), `<td><a href="https://example.com/a">a</a></td>`))
+	is.True(strings.Contains(out.String(), `<td><a href="https://example.org/b">b</a></td>`))
+}
@@ -0,0 +1,87 @@
+package table
+
+import (
+	"io"
+	"os"
+)
+
+const (
+	osc8Start = "\x1b]8;;"
+	osc8End   = "\x1b\\"
+)
+
+// Hyperlink wraps text in OSC 8 sequences that make it a clickable link
+// to url in supporting terminals
+func Hyperlink(url string, text string) string {
+	return osc8Start + url + osc8End + text + osc8Start + osc8End
+}
+
+// HyperlinksEnabled reports whether OSC 8 hyperlinks should be written to
+// out: out must be a terminal with colors enabled, see ColorEnabled
+func HyperlinksEnabled(out io.Writer) bool {
+	if os.Getenv("TERM") == "dumb" || !ColorEnabled(out) {
+		return false
+	}
+	return isTerminal(out)
+}
+
+// SetHyperlinks enables or disables OSC 8 hyperlinks in FormatItem
+// for columns whose Getter implements LinkGetter
+func (t *Table) SetHyperlinks(enabled bool) {
+	t.hyperlinks = enabled
+}
+
+// DetectHyperlinks enables OSC 8 hyperlinks if HyperlinksEnabled(out)
+func (t *Table) DetectHyperlinks(out io.Writer) {
+	t.hyperlinks = HyperlinksEnabled(out)
+}
+
+// columnLink returns the link of the cell if col.Getter is a LinkGetter
+func columnLink(col *Column, item any) (string, error) {
+	linkGetter, ok := col.Getter.(LinkGetter)
+	if !ok {
+		return "", nil
+	}
+	return linkGetter.Link(item)
+}
+
+// linkTarget returns url of the first OSC 8 hyperlink in str
+// and str without OSC 8 sequences
+func linkTarget(str string) (url string, text string) {
+	hasLink := false
+	ForEachToken(str, func(tok Token) {
+		if tok.Kind != TokenOSC {
+			text += tok.Text
+			return
+		}
+		params, ok := osc8Params(tok.Text)
+		if !ok {
+			text += tok.Text
+			return
+		}
+		hasLink = true
+		if url == "" {
+			url = params
+		}
+	})
+	if !hasLink {
+		return "", str
+	}
+	return url, text
+}
+
+// osc8Params returns the URI of an OSC 8 token ("" for closing token)
+func osc8Params(osc string) (string, bool) {
+	body, ok := controlParams(osc, c1OSC, ']')
+	if !ok || len(body) < 2 || body[:2] != "8;" {
+		return "", false
+	}
+	body = trimTerminator(body[2:])
+	// body is "params;URI", params are optional key=value pairs
+	for i := 0; i < len(body); i++ {
+		if body[i] == ';' {
+			return body[i+1:], true
+		}
+	}
+	return "", false
+}
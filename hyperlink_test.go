@@ -0,0 +1,74 @@
+package table
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+type testLinkGetter struct {
+	testGetter
+}
+
+func (g testLinkGetter) Link(item any) (string, error) {
+	name := item.([]any)[g.index].(string)
+	if name == "" {
+		return "", nil
+	}
+	return "https://example.com/" + name, nil
+}
+
+func TestHyperlinks(t *testing.T) {
+	is := is.New(t)
+	tab := newTestTable("name", "size")
+	tab.Columns[0].Getter = testLinkGetter{testGetter{index: 0}}
+	tab.Columns[1].Alignment = AlignmentRight
+	// disabled until enabled for an output
+	formatted, err := tab.FormatItem([]any{"a|b", 12})
+	is.NotErr(err)
+	is.Equal(formatted[0], "a|b")
+
+	tab.SetColor(false)
+	tab.SetHyperlinks(false)
+	formatted, err = tab.FormatItem([]any{"a|b", 12})
+	is.NotErr(err)
+	is.Equal(formatted[0], "a|b")
+
+	tab.SetHyperlinks(true)
+	formatted, err = tab.FormatItem([]any{"さの", 12})
+	is.NotErr(err)
+	is.Equal(formatted[0], "\x1b]8;;https://example.com/さの\x1b\\さの\x1b]8;;\x1b\\")
+	is.Equal(tab.Width("name"), uint16(4))
+
+	out := &strings.Builder{}
+	is.NotErr(tab.WriteMarkdown(out, []any{
+		[]any{"a|b", 12},
+		[]any{"", 1024},
+	}))
+	is.Equal(out.String(), ""+
+		"| name                              | size |\n"+
+		"| --------------------------------- | ---: |\n"+
+		"| [a\\|b](https://example.com/a%7Cb) | 12   |\n"+
+		"|                                   | 1024 |\n",
+	)
+}
+
+func TestOSC8Params(t *testing.T) {
+	is := is.New(t)
+	for _, link := range []string{
+		"\x1b]8;;https://example.com\x1b\\",
+		"\x1b]8;id=1;https://example.com\x07",
+		"\u009d8;;https://example.com\u009c",
+		"\x9d8;;https://example.com\x9c",
+	} {
+		url, ok := osc8Params(link)
+		is.True(ok)
+		is.Equal(url, "https://example.com")
+		url, text := linkTarget(link + "x")
+		is.Equal(url, "https://example.com")
+		is.Equal(text, "x")
+	}
+	_, ok := osc8Params("\x9d0;title\x9c")
+	is.False(ok)
+}
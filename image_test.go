@@ -0,0 +1,54 @@
+package table
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestParseScreen(t *testing.T) {
+	is := is.New(t)
+	grid := parseScreen("a" + Fg(1) + "さ\n" + reset + "é\n")
+	is.Equal(len(grid), 2)
+	is.Equal(len(grid[0]), 3)
+	is.Equal(grid[0][1], screenCell{text: "さ", style: Style{Fg: Color256(1)}, width: 2})
+	is.True(grid[0][2].cont)
+	is.Equal(grid[1], []screenCell{{text: "é", width: 1}})
+}
+
+func TestRenderSVG(t *testing.T) {
+	is := is.New(t)
+	out := &strings.Builder{}
+	is.NotErr(RenderSVG(out, "ab"+"\x1b[1;31mさの\x1b[0m", &ImageOptions{FontSize: 10}))
+	svg := out.String()
+	is.True(strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="48" height="24"`))
+	is.True(strings.Contains(svg, `<text x="6" y="15.6" fill="#d4d4d4" textLength="12" lengthAdjust="spacingAndGlyphs">ab</text>`))
+	is.True(strings.Contains(svg, `<text x="18" y="15.6" fill="#cd0000" font-weight="bold" textLength="24" lengthAdjust="spacingAndGlyphs">さの</text>`))
+}
+
+func TestWritePNG(t *testing.T) {
+	is := is.New(t)
+	tab := newTestTable("name", "size")
+	tab.SetColor(false)
+	items := FormattedItems{}
+	for _, item := range [][]any{{"さの.png", 12}, {"a.txt", 1024}} {
+		formatted, err := tab.FormatItem(item)
+		is.NotErr(err)
+		items = append(items, formatted)
+	}
+	buf := &bytes.Buffer{}
+	is.NotErr(tab.WritePNG(buf, items, BorderLight, &ImageOptions{Scale: 1}))
+	img, err := png.Decode(buf)
+	is.NotErr(err)
+	// 19 columns and 6 lines, plus margins
+	is.Equal(img.Bounds().Dx(), (19+2)*fontCellW)
+	is.Equal(img.Bounds().Dy(), (6+1)*fontCellH)
+	// top left corner of the border
+	r, g, b, _ := img.At(fontCellW+2, fontCellH/2+4).RGBA()
+	is.Equal([]uint32{r >> 8, g >> 8, b >> 8}, []uint32{0xd4, 0xd4, 0xd4})
+	r, g, b, _ = img.At(0, 0).RGBA()
+	is.Equal([]uint32{r >> 8, g >> 8, b >> 8}, []uint32{0x1e, 0x1e, 0x1e})
+}
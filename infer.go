@@ -0,0 +1,421 @@
+package table
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ByteSize is a number of bytes, parsed from sizes like "1.5G" or "512KiB"
+type ByteSize int64
+
+var byteSizeUnits = []string{"", "K", "M", "G", "T", "P", "E"}
+
+// String formats the size like ls -h, with a 1024-based unit and
+// one decimal place below 10
+func (s ByteSize) String() string {
+	f := float64(s)
+	sign := ""
+	if f < 0 {
+		sign = "-"
+		f = -f
+	}
+	unitI := 0
+	for f >= 1024 && unitI < len(byteSizeUnits)-1 {
+		f /= 1024
+		unitI++
+	}
+	if unitI == 0 {
+		return sign + strconv.FormatFloat(f, 'f', 0, 64)
+	}
+	if f < 10 && math.Round(f*10) < 100 {
+		return sign + strconv.FormatFloat(math.Round(f*10)/10, 'f', -1, 64) + byteSizeUnits[unitI]
+	}
+	return sign + strconv.FormatFloat(math.Round(f), 'f', 0, 64) + byteSizeUnits[unitI]
+}
+
+var byteSizeRegexp = regexp.MustCompile(`^(-?[0-9]+(?:\.[0-9]+)?) ?([kKMGTPE]?)(i?)([bB]?)$`)
+
+// ParseByteSize parses sizes like "512", "1.5G", "10MiB" or "3 kB".
+// Units without "i" and with "B" (like "kB" and "MB") are 1000-based,
+// others (like "K", "Mi" and "GiB") are 1024-based
+func ParseByteSize(str string) (ByteSize, error) {
+	match := byteSizeRegexp.FindStringSubmatch(strings.TrimSpace(str))
+	if match == nil {
+		return 0, fmt.Errorf("invalid byte size %#v", str)
+	}
+	num, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, err
+	}
+	unit, binary, suffix := strings.ToUpper(match[2]), match[3] != "", match[4]
+	if unit == "" {
+		if binary {
+			return 0, fmt.Errorf("invalid byte size %#v", str)
+		}
+		return ByteSize(math.Round(num)), nil
+	}
+	base := 1024.0
+	if !binary && suffix != "" {
+		base = 1000
+	}
+	for _, u := range byteSizeUnits[1:] {
+		num *= base
+		if u == unit {
+			break
+		}
+	}
+	return ByteSize(math.Round(num)), nil
+}
+
+var (
+	int64Type    = reflect.TypeOf(int64(0))
+	float64Type  = reflect.TypeOf(float64(0))
+	boolType     = reflect.TypeOf(false)
+	durationType = reflect.TypeOf(time.Duration(0))
+	byteSizeType = reflect.TypeOf(ByteSize(0))
+)
+
+// InferTimeLayouts are time layouts tried in order by InferType
+var InferTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02 15:04:05",
+	"2006/01/02",
+	"01/02/2006 15:04:05",
+	"01/02/2006",
+	"02.01.2006",
+	"2 Jan 2006",
+	"Jan 2, 2006",
+	"Jan _2 15:04",
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.RFC822Z,
+	time.RFC822,
+	time.UnixDate,
+	time.ANSIC,
+}
+
+var clockDurationRegexp = regexp.MustCompile(`^(-?)(?:([0-9]+)-)?([0-9]+):([0-5][0-9]):([0-5][0-9](?:\.[0-9]+)?)$`)
+
+// parseDuration parses Go durations like "1h30m" and clock durations like
+// "00:01:02", "123:00:00" or "2-03:00:00" (with days, like ps)
+func parseDuration(str string) (time.Duration, error) {
+	match := clockDurationRegexp.FindStringSubmatch(str)
+	if match == nil {
+		if !strings.ContainsAny(str, "hmsuµn") {
+			return 0, fmt.Errorf("invalid duration %#v", str)
+		}
+		return time.ParseDuration(str)
+	}
+	days, _ := strconv.ParseInt("0"+match[2], 10, 64)
+	hours, _ := strconv.ParseInt(match[3], 10, 64)
+	minutes, _ := strconv.ParseInt(match[4], 10, 64)
+	seconds, _ := strconv.ParseFloat(match[5], 64)
+	d := time.Duration(days)*24*time.Hour + time.Duration(hours)*time.Hour +
+		time.Duration(minutes)*time.Minute + time.Duration(seconds*float64(time.Second))
+	if match[1] != "" {
+		d = -d
+	}
+	return d, nil
+}
+
+var floatRegexp = regexp.MustCompile(`^[-+]?(?:[0-9]+\.?[0-9]*|\.[0-9]+)(?:[eE][-+]?[0-9]+)?$`)
+
+func parseBool(str string) (bool, error) {
+	switch strings.ToLower(str) {
+	case "true", "yes":
+		return true, nil
+	case "false", "no":
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid bool %#v", str)
+}
+
+// InferredType is the type of a column inferred from its values
+type InferredType struct {
+	Type reflect.Type
+	// Layout is the time layout of time.Time columns
+	Layout string
+	// Confidence is the fraction of non-empty sampled values that
+	// are valid values of Type
+	Confidence float64
+}
+
+// Parse parses str as a value of Type, it returns nil for empty strings
+func (it *InferredType) Parse(str string) (any, error) {
+	str = strings.TrimSpace(str)
+	if str == "" {
+		return nil, nil
+	}
+	switch it.Type {
+	case int64Type:
+		return strconv.ParseInt(str, 10, 64)
+	case float64Type:
+		if !floatRegexp.MatchString(str) {
+			return nil, fmt.Errorf("invalid number %#v", str)
+		}
+		return strconv.ParseFloat(str, 64)
+	case boolType:
+		return parseBool(str)
+	case durationType:
+		return parseDuration(str)
+	case byteSizeType:
+		return ParseByteSize(str)
+	case timeType:
+		return time.Parse(it.Layout, str)
+	case stringType, nil:
+		return str, nil
+	}
+	return nil, fmt.Errorf("unsupported type %v", it.Type)
+}
+
+// IsNumeric returns true for integer, float, duration and byte size types
+func (it *InferredType) IsNumeric() bool {
+	switch it.Type {
+	case int64Type, float64Type, durationType, byteSizeType:
+		return true
+	}
+	return false
+}
+
+// InferOptions are options of InferType and TextTable.InferTypes
+type InferOptions struct {
+	// Threshold is the minimum Confidence of an inferred type, default 0.95
+	Threshold float64
+	// SampleSize is the maximum number of values checked, default 1000
+	SampleSize int
+	// Types sets the type of columns by name instead of inferring it,
+	// use reflect.TypeOf("") to keep a column as string
+	Types map[string]reflect.Type
+	// TimeLayouts sets the layout of time.Time columns by name
+	TimeLayouts map[string]string
+}
+
+func (opts *InferOptions) threshold() float64 {
+	if opts.Threshold <= 0 {
+		return 0.95
+	}
+	return opts.Threshold
+}
+
+// sample returns non-empty values, up to SampleSize
+func (opts *InferOptions) sample(values []string) []string {
+	size := opts.SampleSize
+	if size <= 0 {
+		size = 1000
+	}
+	sample := make([]string, 0, size)
+	for _, value := range values {
+		if len(sample) == size {
+			break
+		}
+		if value = strings.TrimSpace(value); value != "" {
+			sample = append(sample, value)
+		}
+	}
+	return sample
+}
+
+// confidence returns the fraction of sample that are valid values of it
+func (it *InferredType) confidence(sample []string) float64 {
+	valid := 0
+	for _, value := range sample {
+		if _, err := it.Parse(value); err == nil {
+			valid++
+		}
+	}
+	return float64(valid) / float64(len(sample))
+}
+
+// inferCandidates are types tried in order by InferType, byte sizes
+// come after numbers since plain numbers are valid byte sizes
+var inferCandidates = []reflect.Type{
+	int64Type,
+	float64Type,
+	boolType,
+	durationType,
+	byteSizeType,
+}
+
+// InferType infers the type of a column from its values: int64, float64,
+// bool, time.Duration, ByteSize or time.Time (with a layout from
+// InferTimeLayouts), the first type with a Confidence of at least the
+// threshold is used. Empty values are ignored, and a column with no
+// values (or no matching type) is a string column
+func InferType(values []string, opts *InferOptions) *InferredType {
+	if opts == nil {
+		opts = &InferOptions{}
+	}
+	sample := opts.sample(values)
+	if len(sample) == 0 {
+		return &InferredType{Type: stringType, Confidence: 1}
+	}
+	threshold := opts.threshold()
+	for _, typ := range inferCandidates {
+		it := &InferredType{Type: typ}
+		if it.Confidence = it.confidence(sample); it.Confidence >= threshold {
+			return it
+		}
+	}
+	if it := inferTimeLayout(sample); it.Confidence >= threshold {
+		return it
+	}
+	return &InferredType{Type: stringType, Confidence: 1}
+}
+
+// inferTimeLayout returns the layout of InferTimeLayouts with
+// the highest confidence
+func inferTimeLayout(sample []string) *InferredType {
+	best := &InferredType{Type: timeType}
+	for _, layout := range InferTimeLayouts {
+		it := &InferredType{Type: timeType, Layout: layout}
+		if it.Confidence = it.confidence(sample); it.Confidence > best.Confidence {
+			best = it
+		}
+		if best.Confidence == 1 {
+			break
+		}
+	}
+	return best
+}
+
+// CompareValues compares two values returned by InferredType.Parse, or by
+// Value of typed getters, and returns -1, 0 or 1. Nil is less than other
+// values and values of different types are compared as strings
+func CompareValues(a any, b any) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+	switch va := a.(type) {
+	case time.Time:
+		vb := b.(time.Time)
+		switch {
+		case va.Before(vb):
+			return -1
+		case va.After(vb):
+			return 1
+		}
+		return 0
+	case bool:
+		vb := b.(bool)
+		switch {
+		case va == vb:
+			return 0
+		case !va:
+			return -1
+		}
+		return 1
+	case string:
+		return strings.Compare(va, b.(string))
+	}
+	ra, rb := reflect.ValueOf(a), reflect.ValueOf(b)
+	switch ra.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compareOrdered(ra.Int(), rb.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return compareOrdered(ra.Uint(), rb.Uint())
+	case reflect.Float32, reflect.Float64:
+		return compareOrdered(ra.Float(), rb.Float())
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func compareOrdered[T int64 | uint64 | float64](a T, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// typedCellGetter is a Getter of TextTable rows with values parsed
+// as an inferred type, Format returns the original text
+type typedCellGetter struct {
+	textCellGetter
+	typ *InferredType
+}
+
+// Value returns the parsed value, or nil for empty and invalid values
+func (g typedCellGetter) Value(item any) (any, error) {
+	str, err := g.textCellGetter.Value(item)
+	if err != nil {
+		return nil, err
+	}
+	value, err := g.typ.Parse(str.(string))
+	if err != nil {
+		return nil, nil
+	}
+	return value, nil
+}
+
+func (g typedCellGetter) Format(item any, value any) (string, error) {
+	str, err := g.textCellGetter.Value(item)
+	if err != nil {
+		return "", err
+	}
+	return str.(string), nil
+}
+
+// InferTypes infers the type of each column (see InferType) or uses
+// opts.Types, sets Column.Type and installs a Getter whose Value returns
+// parsed values (nil for empty and invalid values), while cells are
+// formatted as the original text. Numeric columns that are aligned to the
+// left (or have no Alignment) are aligned to the right. Returns the types
+// by column name
+func (tt *TextTable) InferTypes(opts *InferOptions) map[string]*InferredType {
+	if opts == nil {
+		opts = &InferOptions{}
+	}
+	types := make(map[string]*InferredType, len(tt.Columns))
+	for colI, col := range tt.Columns {
+		values := make([]string, len(tt.Rows))
+		for rowI, row := range tt.Rows {
+			values[rowI] = row[colI]
+		}
+		var it *InferredType
+		if typ, ok := opts.Types[col.Name]; ok {
+			it = &InferredType{Type: typ, Layout: opts.TimeLayouts[col.Name]}
+			if typ == timeType && it.Layout == "" {
+				it.Layout = inferTimeLayout(opts.sample(values)).Layout
+			}
+			if sample := opts.sample(values); len(sample) > 0 {
+				it.Confidence = it.confidence(sample)
+			}
+		} else {
+			it = InferType(values, opts)
+			if layout, ok := opts.TimeLayouts[col.Name]; ok && it.Type == timeType {
+				it.Layout = layout
+			}
+		}
+		types[col.Name] = it
+		col.Type = it.Type
+		col.Getter = typedCellGetter{
+			textCellGetter: textCellGetter{index: colI},
+			typ:            it,
+		}
+		if it.IsNumeric() && (col.Alignment == nil || alignmentName(col.Alignment) == "left") {
+			col.Alignment = AlignmentRight
+		}
+	}
+	return types
+}
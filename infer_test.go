@@ -0,0 +1,139 @@
+package table
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestParseByteSize(t *testing.T) {
+	is := is.New(t)
+	for str, expected := range map[string]ByteSize{
+		"512":    512,
+		"1.5G":   1610612736,
+		"1.5 G":  1610612736,
+		"10MiB":  10 * 1024 * 1024,
+		"3 kB":   3000,
+		"2MB":    2000000,
+		"4k":     4096,
+		"-1K":    -1024,
+		"0":      0,
+		"7.25Ki": 7424,
+	} {
+		size, err := ParseByteSize(str)
+		is.Msg("str=%#v", str).NotErr(err)
+		is.Msg("str=%#v", str).Equal(size, expected)
+	}
+	for _, str := range []string{"", "G", "1.5X", "10 iB", "1.2.3K", "12Gb/s"} {
+		_, err := ParseByteSize(str)
+		is.Msg("str=%#v", str).Err(err)
+	}
+	is.Equal(ByteSize(512).String(), "512")
+	is.Equal(ByteSize(1610612736).String(), "1.5G")
+	is.Equal(ByteSize(10*1024*1024).String(), "10M")
+	is.Equal(ByteSize(1023*1024).String(), "1023K")
+	is.Equal(ByteSize(-2048).String(), "-2K")
+}
+
+func TestInferType(t *testing.T) {
+	is := is.New(t)
+	test := func(values []string, typ reflect.Type, layout string) {
+		it := InferType(values, nil)
+		is.Msg("values=%#v", values).Equal(it.Type, typ)
+		is.Msg("values=%#v", values).Equal(it.Layout, layout)
+	}
+	test([]string{"1", "-22", "", "333"}, int64Type, "")
+	test([]string{"1", "2.5", "1e3"}, float64Type, "")
+	test([]string{"true", "False", "yes"}, boolType, "")
+	test([]string{"1h30m", "2s", "00:00:03", "2-03:00:00"}, durationType, "")
+	test([]string{"512", "1.5G", "3K"}, byteSizeType, "")
+	test([]string{"2024-01-05", "2023-12-30"}, timeType, "2006-01-02")
+	test([]string{"2024-01-05T10:00:00Z", "2024-01-05T10:00:02.5+03:30"}, timeType, time.RFC3339Nano)
+	test([]string{"Jan  5 10:00", "Dec 30 23:59"}, timeType, "Jan _2 15:04")
+	test([]string{"a", "1"}, stringType, "")
+	test([]string{"", " "}, stringType, "")
+	test(nil, stringType, "")
+
+	values := make([]string, 100)
+	for i := range values {
+		values[i] = "42"
+	}
+	values[7] = "n/a"
+	it := InferType(values, nil)
+	is.Equal(it.Type, int64Type)
+	is.Equal(it.Confidence, 0.99)
+	it = InferType(values, &InferOptions{Threshold: 1})
+	is.Equal(it.Type, stringType)
+	it = InferType(values, &InferOptions{Threshold: 1, SampleSize: 7})
+	is.Equal(it.Type, int64Type)
+}
+
+func TestCompareValues(t *testing.T) {
+	is := is.New(t)
+	is.Equal(CompareValues(nil, nil), 0)
+	is.Equal(CompareValues(nil, int64(1)), -1)
+	is.Equal(CompareValues(int64(1), nil), 1)
+	is.Equal(CompareValues(int64(2), int64(10)), -1)
+	is.Equal(CompareValues(2.5, 2.5), 0)
+	is.Equal(CompareValues(ByteSize(2048), ByteSize(1000)), 1)
+	is.Equal(CompareValues(time.Second, time.Minute), -1)
+	is.Equal(CompareValues(false, true), -1)
+	is.Equal(CompareValues("b", "a"), 1)
+	now := time.Now()
+	is.Equal(CompareValues(now, now.Add(time.Hour)), -1)
+	is.Equal(CompareValues(int64(10), "9"), -1)
+}
+
+func TestTextTableInferTypes(t *testing.T) {
+	is := is.New(t)
+	parsed, err := ParseCSV(strings.NewReader(`name,size,used,mtime,ok,elapsed
+a,1.5G,10,2024-01-05,true,1m
+b,512,n/a,2024-01-06,false,00:01:02
+c,2K,3,,yes,2s
+`), nil)
+	is.NotErr(err)
+	types := parsed.InferTypes(&InferOptions{
+		Threshold: 0.6,
+		Types:     map[string]reflect.Type{"ok": stringType},
+	})
+	is.Equal(types["name"].Type, stringType)
+	is.Equal(types["size"].Type, byteSizeType)
+	is.Equal(types["used"].Type, int64Type)
+	is.Equal(types["used"].Confidence, 2.0/3)
+	is.Equal(types["mtime"].Type, timeType)
+	is.Equal(types["mtime"].Layout, "2006-01-02")
+	is.Equal(types["ok"].Type, stringType)
+	is.Equal(types["elapsed"].Type, durationType)
+	is.Equal(parsed.Columns[1].Type, byteSizeType)
+	is.Equal(alignmentName(parsed.Columns[1].Alignment), "right")
+	is.Equal(alignmentName(parsed.Columns[2].Alignment), "right")
+
+	value := func(colI int, rowI int) any {
+		value, err := parsed.Columns[colI].Getter.Value(parsed.Rows[rowI])
+		is.NotErr(err)
+		return value
+	}
+	is.Equal(value(1, 0), ByteSize(1610612736))
+	is.Equal(value(2, 1), nil)
+	is.Equal(value(3, 2), nil)
+	is.Equal(value(3, 1), time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC))
+	is.Equal(value(4, 2), "yes")
+	is.Equal(value(5, 1), 62*time.Second)
+
+	tab := parsed.Table()
+	tab.SetColor(false)
+	formatted, err := tab.FormatItem(parsed.Rows[1])
+	is.NotErr(err)
+	is.Equal(formatted, []string{"b", "512", "n/a", "2024-01-06", "false", "00:01:02"})
+
+	parsed, err = ParseCSV(strings.NewReader("when\n05/01/2024\n06/01/2024\n"), nil)
+	is.NotErr(err)
+	types = parsed.InferTypes(&InferOptions{
+		TimeLayouts: map[string]string{"when": "02/01/2006"},
+	})
+	is.Equal(types["when"].Layout, "02/01/2006")
+	is.Equal(value(0, 1), time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC))
+}
@@ -0,0 +1,136 @@
+package table
+
+import (
+	"io"
+	"strings"
+)
+
+// LaTeXOptions are options of WriteLaTeX
+type LaTeXOptions struct {
+	Caption string
+	Label   string
+	// Longtable uses longtable environment (package longtable) instead of
+	// tabular, so the table can span multiple pages
+	Longtable bool
+	// Booktabs uses \toprule, \midrule and \bottomrule (package booktabs)
+	// instead of \hline
+	Booktabs bool
+}
+
+var latexEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`&`, `\&`,
+	`%`, `\%`,
+	`$`, `\$`,
+	`#`, `\#`,
+	`_`, `\_`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`,
+	"\n", `\newline{}`,
+)
+
+// latexCell escapes a formatted cell, text in ANSI bold is put in \textbf
+// and other control sequences are dropped
+func latexCell(str string) string {
+	var sb strings.Builder
+	style := Style{}
+	ForEachToken(str, func(tok Token) {
+		switch tok.Kind {
+		case TokenText:
+			text := latexEscaper.Replace(tok.Text)
+			if style.Bold {
+				text = `\textbf{` + text + `}`
+			}
+			sb.WriteString(text)
+		case TokenCSI:
+			if params, ok := sgrTokenParams(tok); ok {
+				style = applySGR(style, params)
+			}
+		}
+	})
+	return sb.String()
+}
+
+func latexColumnSpec(al Alignment) string {
+	switch alignmentName(al) {
+	case "right":
+		return "r"
+	case "center":
+		return "c"
+	}
+	return "l"
+}
+
+// WriteLaTeX writes items as a LaTeX tabular (or longtable) environment,
+// column spec is derived from Column.Alignment
+func (t *Table) WriteLaTeX(out io.Writer, items []any, opts *LaTeXOptions) error {
+	if opts == nil {
+		opts = &LaTeXOptions{}
+	}
+	topRule, midRule, bottomRule := `\hline`, `\hline`, `\hline`
+	if opts.Booktabs {
+		topRule, midRule, bottomRule = `\toprule`, `\midrule`, `\bottomrule`
+	}
+	spec := ""
+	header := make([]string, t.ColumnCount())
+	for i, col := range t.Columns {
+		spec += latexColumnSpec(col.Alignment)
+		header[i] = latexEscaper.Replace(col.Title)
+	}
+	captionLines := []string{}
+	if opts.Caption != "" {
+		captionLines = append(captionLines, `\caption{`+latexEscaper.Replace(opts.Caption)+`}`)
+	}
+	if opts.Label != "" {
+		captionLines = append(captionLines, `\label{`+opts.Label+`}`)
+	}
+	lines := []string{}
+	env := "tabular"
+	if opts.Longtable {
+		env = "longtable"
+		lines = append(lines, `\begin{longtable}{`+spec+`}`)
+		if len(captionLines) > 0 {
+			lines = append(lines, strings.Join(captionLines, "")+` \\`)
+		}
+		lines = append(lines,
+			topRule,
+			strings.Join(header, " & ")+` \\`,
+			midRule,
+			`\endhead`,
+		)
+	} else {
+		if len(captionLines) > 0 {
+			lines = append(lines, `\begin{table}[htbp]`, `\centering`)
+			lines = append(lines, captionLines...)
+		}
+		lines = append(lines,
+			`\begin{tabular}{`+spec+`}`,
+			topRule,
+			strings.Join(header, " & ")+` \\`,
+			midRule,
+		)
+	}
+	for _, item := range items {
+		cells := make([]string, t.ColumnCount())
+		for i, col := range t.Columns {
+			value, err := col.Getter.Value(item)
+			if err != nil {
+				return err
+			}
+			formatted, err := col.Getter.Format(item, value)
+			if err != nil {
+				return err
+			}
+			cells[i] = latexCell(formatted)
+		}
+		lines = append(lines, strings.Join(cells, " & ")+` \\`)
+	}
+	lines = append(lines, bottomRule, `\end{`+env+`}`)
+	if !opts.Longtable && len(captionLines) > 0 {
+		lines = append(lines, `\end{table}`)
+	}
+	_, err := io.WriteString(out, strings.Join(lines, "\n")+"\n")
+	return err
+}
@@ -0,0 +1,51 @@
+package table
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestWriteLaTeX(t *testing.T) {
+	is := is.New(t)
+	tab := newTestTable("name", "share")
+	tab.Columns[1].Alignment = AlignmentRight
+	tab.Columns[1].Title = "share %"
+	items := []any{
+		[]any{"a_b & c", "10%"},
+		[]any{"\x1b[1mbold\x1b[0m {x}", "$5"},
+	}
+	out := &strings.Builder{}
+	is.NotErr(tab.WriteLaTeX(out, items, &LaTeXOptions{
+		Booktabs: true,
+		Caption:  "Shares",
+		Label:    "tab:shares",
+	}))
+	is.Equal(out.String(), `\begin{table}[htbp]
+\centering
+\caption{Shares}
+\label{tab:shares}
+\begin{tabular}{lr}
+\toprule
+name & share \% \\
+\midrule
+a\_b \& c & 10\% \\
+\textbf{bold} \{x\} & \$5 \\
+\bottomrule
+\end{tabular}
+\end{table}
+`)
+
+	out.Reset()
+	is.NotErr(tab.WriteLaTeX(out, items[:1], &LaTeXOptions{Longtable: true}))
+	is.Equal(out.String(), `\begin{longtable}{lr}
+\hline
+name & share \% \\
+\hline
+\endhead
+a\_b \& c & 10\% \\
+\hline
+\end{longtable}
+`)
+}
@@ -0,0 +1,201 @@
+package table
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+)
+
+// LiveTable re-renders a table of items returned by a refresh function,
+// like top. On a terminal, the table is redrawn in place and only changed
+// lines are rewritten, lines are truncated to the terminal width and rows
+// that do not fit the terminal height are not shown. Otherwise each update
+// is appended to the output, separated by an empty line.
+// Column widths never shrink, so columns do not jitter between updates
+type LiveTable struct {
+	table   *Table
+	out     io.Writer
+	refresh func() ([]any, error)
+
+	// Sep is the column separator, default is two spaces
+	Sep string
+
+	tty    bool
+	width  int
+	height int
+	// lines are the lines drawn by the last update
+	lines   []string
+	resized bool
+	updates int
+}
+
+// NewLiveTable creates a LiveTable writing to out, out is redrawn in place
+// if it is a terminal
+func NewLiveTable(t *Table, out io.Writer, refresh func() ([]any, error)) *LiveTable {
+	lt := &LiveTable{
+		table:   t,
+		out:     out,
+		refresh: refresh,
+		Sep:     "  ",
+		tty:     isTerminal(out),
+	}
+	lt.querySize()
+	return lt
+}
+
+// querySize sets the size from the terminal, if out is a terminal
+func (lt *LiveTable) querySize() {
+	f, ok := lt.out.(*os.File)
+	if !ok || !lt.tty {
+		return
+	}
+	if width, height, err := terminalSize(int(f.Fd())); err == nil && width > 0 && height > 0 {
+		lt.SetSize(width, height)
+	}
+}
+
+// SetSize sets size of the terminal, the whole table is redrawn by the
+// next update. Run sets it from the terminal when it is resized
+func (lt *LiveTable) SetSize(width int, height int) {
+	lt.width, lt.height = width, height
+	lt.resized = true
+}
+
+// render formats items and returns the lines of the table
+func (lt *LiveTable) render(items []any) ([]string, error) {
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		row, err := lt.table.FormatItem(item)
+		if err != nil {
+			return nil, err
+		}
+		rows[i] = row
+	}
+	widths := map[string]uint16{}
+	for _, col := range lt.table.Columns {
+		widths[col.Name] = visualWidth(col.Title)
+	}
+	lt.table.UpdateWidth(widths)
+	sep := lt.table.styledSeparator(lt.Sep)
+	lines := make([]string, 0, len(rows)+1)
+	lines = append(lines, strings.Join(lt.table.headerCells(), sep))
+	for rowI, row := range rows {
+		cells := make([]string, len(row))
+		for colI, col := range lt.table.Columns {
+			cells[colI] = lt.table.alignCell(colI, row[colI], lt.table.Width(col.Name))
+		}
+		lines = append(lines, lt.table.styleRow(strings.Join(cells, sep), rowI))
+	}
+	return lines, nil
+}
+
+// fit truncates lines to the terminal size, the last line of the terminal
+// is kept empty for the cursor
+func (lt *LiveTable) fit(lines []string) []string {
+	if lt.height > 1 && len(lines) > lt.height-1 {
+		lines = lines[:lt.height-1]
+	}
+	if lt.width <= 0 {
+		return lines
+	}
+	for i, line := range lines {
+		if int(visualWidth(line)) > lt.width {
+			lines[i] = TruncateWidth(line, uint16(lt.width)) + "\x1b[0m"
+		}
+	}
+	return lines
+}
+
+// redraw returns the output that changes the drawn lines to lines.
+// The cursor is at the start of the line after the last drawn line
+func (lt *LiveTable) redraw(lines []string) string {
+	var sb strings.Builder
+	prev := lt.lines
+	if len(prev) > 0 {
+		fmt.Fprintf(&sb, "\r\x1b[%dA", len(prev))
+	}
+	if lt.resized {
+		// lines may have been wrapped or moved by the terminal
+		sb.WriteString("\x1b[J")
+		prev = nil
+	}
+	skip := 0
+	for i, line := range lines {
+		if i < len(prev) && prev[i] == line {
+			skip++
+			continue
+		}
+		if skip > 0 {
+			fmt.Fprintf(&sb, "\x1b[%dB", skip)
+			skip = 0
+		}
+		sb.WriteString(line + "\x1b[K\n")
+	}
+	if skip > 0 {
+		fmt.Fprintf(&sb, "\x1b[%dB", skip)
+	}
+	if len(lines) < len(prev) {
+		sb.WriteString("\x1b[J")
+	}
+	return sb.String()
+}
+
+// Update calls the refresh function and writes the table
+func (lt *LiveTable) Update() error {
+	items, err := lt.refresh()
+	if err != nil {
+		return err
+	}
+	lines, err := lt.render(items)
+	if err != nil {
+		return err
+	}
+	if !lt.tty {
+		output := strings.Join(lines, "\n") + "\n"
+		if lt.updates > 0 {
+			output = "\n" + output
+		}
+		lt.updates++
+		_, err := io.WriteString(lt.out, output)
+		return err
+	}
+	lines = lt.fit(lines)
+	output := lt.redraw(lines)
+	lt.lines = lines
+	lt.resized = false
+	lt.updates++
+	_, err = io.WriteString(lt.out, output)
+	return err
+}
+
+// Run calls Update every interval until stop is closed or an error occurs.
+// On a terminal, the cursor is hidden while running and the table is
+// redrawn immediately when the terminal is resized
+func (lt *LiveTable) Run(interval time.Duration, stop <-chan struct{}) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	resize := make(chan os.Signal, 1)
+	if lt.tty {
+		notifyResize(resize)
+		defer signal.Stop(resize)
+		if _, err := io.WriteString(lt.out, "\x1b[?25l"); err != nil {
+			return err
+		}
+		defer io.WriteString(lt.out, "\x1b[?25h")
+	}
+	for {
+		if err := lt.Update(); err != nil {
+			return err
+		}
+		select {
+		case <-stop:
+			return nil
+		case <-ticker.C:
+		case <-resize:
+			lt.querySize()
+		}
+	}
+}
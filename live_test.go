@@ -0,0 +1,102 @@
+package table
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+// newTestLiveTable returns a LiveTable showing items of the returned slice
+func newTestLiveTable(out *bytes.Buffer, tty bool) (*LiveTable, *[]any) {
+	tab := newTestTable("pid", "cmd", "cpu")
+	tab.SetColor(false)
+	tab.ColumnByName["cpu"].Alignment = AlignmentRight
+	items := &[]any{
+		[]any{1, "init", 0.1},
+		[]any{42, "sshd", 1.5},
+	}
+	lt := NewLiveTable(tab, out, func() ([]any, error) {
+		return *items, nil
+	})
+	lt.tty = tty
+	return lt, items
+}
+
+func TestLiveTableAppend(t *testing.T) {
+	is := is.New(t)
+	out := &bytes.Buffer{}
+	lt, items := newTestLiveTable(out, false)
+	is.NotErr(lt.Update())
+	*items = []any{[]any{1, "init", 12.25}}
+	is.NotErr(lt.Update())
+	is.Equal(out.String(), ""+
+		"pid   cmd  cpu\n"+
+		"1    init  0.1\n"+
+		"42   sshd  1.5\n"+
+		"\n"+
+		"pid   cmd   cpu \n"+
+		"1    init  12.25\n")
+}
+
+func TestLiveTableRedraw(t *testing.T) {
+	is := is.New(t)
+	out := &bytes.Buffer{}
+	lt, items := newTestLiveTable(out, true)
+	is.NotErr(lt.Update())
+	is.Equal(out.String(), ""+
+		"pid   cmd  cpu\x1b[K\n"+
+		"1    init  0.1\x1b[K\n"+
+		"42   sshd  1.5\x1b[K\n")
+
+	// only the changed line is rewritten
+	out.Reset()
+	*items = []any{
+		[]any{1, "init", 0.1},
+		[]any{42, "sshd", 1.7},
+	}
+	is.NotErr(lt.Update())
+	is.Equal(out.String(), "\r\x1b[3A\x1b[2B42   sshd  1.7\x1b[K\n")
+
+	// nothing changed
+	out.Reset()
+	is.NotErr(lt.Update())
+	is.Equal(out.String(), "\r\x1b[3A\x1b[3B")
+
+	// fewer rows, rest of the screen is cleared
+	out.Reset()
+	*items = []any{[]any{1, "init", 0.1}}
+	is.NotErr(lt.Update())
+	is.Equal(out.String(), "\r\x1b[3A\x1b[2B\x1b[J")
+
+	// widths are sticky, wider cells move all lines
+	out.Reset()
+	*items = []any{[]any{1, "systemd", 0.1}}
+	is.NotErr(lt.Update())
+	is.Equal(out.String(), ""+
+		"\r\x1b[2A"+
+		"pid    cmd    cpu\x1b[K\n"+
+		"1    systemd  0.1\x1b[K\n")
+	out.Reset()
+	*items = []any{[]any{1, "init", 0.1}}
+	is.NotErr(lt.Update())
+	is.Equal(out.String(), "\r\x1b[2A\x1b[1B1    init     0.1\x1b[K\n")
+}
+
+func TestLiveTableResize(t *testing.T) {
+	is := is.New(t)
+	out := &bytes.Buffer{}
+	lt, items := newTestLiveTable(out, true)
+	*items = append(*items, []any{7, "kworker", 0.0})
+	is.NotErr(lt.Update())
+
+	// lines are truncated to the width, and rows to the height
+	out.Reset()
+	lt.SetSize(10, 3)
+	is.NotErr(lt.Update())
+	is.Equal(StripANSI(out.String()), ""+
+		"\r"+
+		"pid    cmd\n"+
+		"1    init \n")
+	is.Equal(len(lt.lines), 2)
+}
@@ -0,0 +1,230 @@
+package table
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MapGetter is a Getter of a value in map[string]any items, like decoded
+// JSON objects. Keys is the path of the value in nested maps, like
+// []string{"meta", "owner"} for "meta.owner"
+type MapGetter struct {
+	Keys []string
+}
+
+// NewMapGetter returns a MapGetter of a dotted path like "meta.owner"
+func NewMapGetter(path string) MapGetter {
+	return MapGetter{Keys: strings.Split(path, ".")}
+}
+
+// Value returns the value at Keys, or nil if a key is missing
+func (g MapGetter) Value(item any) (any, error) {
+	value := item
+	for i, key := range g.Keys {
+		m, ok := value.(map[string]any)
+		if !ok {
+			if i == 0 {
+				return nil, fmt.Errorf("invalid item type %T, must be map[string]any", item)
+			}
+			return nil, nil
+		}
+		value = m[key]
+	}
+	return value, nil
+}
+
+func (g MapGetter) ValueString(colName string, item any) (string, error) {
+	value, err := g.Value(item)
+	if err != nil {
+		return "", err
+	}
+	return g.Format(item, value)
+}
+
+// Format returns an empty string for nil, and compact JSON for nested
+// maps and slices. Floats are formatted without exponent
+func (g MapGetter) Format(item any, value any) (string, error) {
+	switch value := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), nil
+	case float32:
+		return strconv.FormatFloat(float64(value), 'f', -1, 32), nil
+	case map[string]any, []any:
+		jsonBytes, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		return string(jsonBytes), nil
+	}
+	return fmt.Sprint(value), nil
+}
+
+type MapKeyOrder uint8
+
+const (
+	// MapKeysFirstSeen orders columns by the first item containing them
+	MapKeysFirstSeen MapKeyOrder = iota
+	// MapKeysSorted sorts keys, keys of a nested map stay together
+	MapKeysSorted
+)
+
+// MapSpecOptions are options of MapSpec
+type MapSpecOptions struct {
+	KeyOrder MapKeyOrder
+}
+
+// mapKeyNode is the union of keys of maps at one path
+type mapKeyNode struct {
+	keys []string
+	// children are nodes of keys whose value is a map in some items
+	children map[string]*mapKeyNode
+	// leaves are keys whose value is not a map in some items
+	leaves map[string]*mapValueKind
+}
+
+// mapValueKind collects types of non-nil values of a column
+type mapValueKind struct {
+	typ     reflect.Type
+	mixed   bool
+	numeric bool
+}
+
+func newMapKeyNode() *mapKeyNode {
+	return &mapKeyNode{
+		children: map[string]*mapKeyNode{},
+		leaves:   map[string]*mapValueKind{},
+	}
+}
+
+func (node *mapKeyNode) add(m map[string]any) {
+	for _, key := range mapKeysInOrder(m) {
+		if node.children[key] == nil && node.leaves[key] == nil {
+			node.keys = append(node.keys, key)
+		}
+		if sub, ok := m[key].(map[string]any); ok {
+			child := node.children[key]
+			if child == nil {
+				child = newMapKeyNode()
+				node.children[key] = child
+			}
+			child.add(sub)
+			continue
+		}
+		kind := node.leaves[key]
+		if kind == nil {
+			kind = &mapValueKind{numeric: true}
+			node.leaves[key] = kind
+		}
+		kind.add(m[key])
+	}
+}
+
+// mapKeysInOrder returns keys of m sorted, since maps have no order
+func mapKeysInOrder(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (kind *mapValueKind) add(value any) {
+	if value == nil {
+		return
+	}
+	typ := reflect.TypeOf(value)
+	if kind.typ == nil && !kind.mixed {
+		kind.typ = typ
+	} else if kind.typ != typ {
+		kind.typ = nil
+		kind.mixed = true
+	}
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return
+	}
+	if _, ok := value.(json.Number); ok {
+		return
+	}
+	kind.numeric = false
+}
+
+func (node *mapKeyNode) columns(prefix []string, order MapKeyOrder) []*Column {
+	keys := node.keys
+	if order == MapKeysSorted {
+		keys = append([]string{}, keys...)
+		sort.Strings(keys)
+	}
+	columns := []*Column{}
+	for _, key := range keys {
+		path := append(append([]string{}, prefix...), key)
+		if kind := node.leaves[key]; kind != nil {
+			name := strings.Join(path, ".")
+			col := &Column{
+				Type:      kind.typ,
+				Getter:    MapGetter{Keys: path},
+				Alignment: AlignmentLeft,
+				Name:      name,
+				Title:     name,
+			}
+			// numeric is true if all values are nil
+			if kind.numeric && kind.typ != nil {
+				col.Alignment = AlignmentRight
+			}
+			columns = append(columns, col)
+		}
+		if child := node.children[key]; child != nil {
+			columns = append(columns, child.columns(path, order)...)
+		}
+	}
+	return columns
+}
+
+// MapSpec returns a TableSpec of the union of keys of items, values of
+// nested maps are columns with dotted names like "meta.owner", and other
+// values (including slices) are formatted by MapGetter. Columns of numbers
+// are right-aligned. opts may be nil.
+// Keys of one map have no order, so with MapKeysFirstSeen, new keys of
+// each item are added in sorted order after keys of previous items
+func MapSpec(items []map[string]any, opts *MapSpecOptions) *TableSpec {
+	if opts == nil {
+		opts = &MapSpecOptions{}
+	}
+	root := newMapKeyNode()
+	for _, m := range items {
+		root.add(m)
+	}
+	spec := NewTableSpec()
+	// a key containing dots can collide with a nested path
+	used := map[string]bool{}
+	for _, col := range root.columns(nil, opts.KeyOrder) {
+		name := col.Name
+		for n := 2; used[name]; n++ {
+			name = col.Name + "_" + strconv.Itoa(n)
+		}
+		used[name] = true
+		col.Name = name
+		spec.AddColumn(col)
+	}
+	return spec
+}
+
+// MapItems converts maps to items of a Table of MapSpec
+func MapItems(maps []map[string]any) []any {
+	items := make([]any, len(maps))
+	for i, m := range maps {
+		items[i] = m
+	}
+	return items
+}
@@ -0,0 +1,90 @@
+package table
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+func decodeMaps(is *is.Is, jsonStr string) []map[string]any {
+	maps := []map[string]any{}
+	is.NotErr(json.Unmarshal([]byte(jsonStr), &maps))
+	return maps
+}
+
+const testMapsJSON = `[
+	{"name": "api", "replicas": 3, "meta": {"owner": "ops", "tags": ["web", "go"]}},
+	{"name": "db", "replicas": 1, "meta": {"owner": "data", "region": "eu"}, "enabled": true},
+	{"name": "cache", "meta": {"owner": null}, "size": 1.5e6, "ports": {"http": 80}}
+]`
+
+func TestMapSpec(t *testing.T) {
+	is := is.New(t)
+	maps := decodeMaps(is, testMapsJSON)
+	spec := MapSpec(maps, nil)
+	is.Equal(columnTitles(spec.Columns), []string{
+		"meta.owner", "meta.tags", "meta.region", "name",
+		"replicas", "enabled", "ports.http", "size",
+	})
+	is.Equal(alignmentNames(spec.Columns), []string{
+		"left", "left", "left", "left",
+		"right", "left", "right", "right",
+	})
+	is.Equal(spec.ColumnByName["replicas"].Type, float64Type)
+	is.Equal(spec.ColumnByName["meta.tags"].Type, reflect.TypeOf([]any{}))
+
+	spec = MapSpec(maps, &MapSpecOptions{KeyOrder: MapKeysSorted})
+	is.Equal(columnTitles(spec.Columns), []string{
+		"enabled", "meta.owner", "meta.region", "meta.tags",
+		"name", "ports.http", "replicas", "size",
+	})
+
+	tab := NewTable(spec)
+	tab.SetColor(false)
+	formatted := FormattedItems{}
+	for _, item := range MapItems(maps) {
+		row, err := tab.FormatItem(item)
+		is.NotErr(err)
+		formatted = append(formatted, row)
+	}
+	is.Equal(formatted, FormattedItems{
+		{"", "ops", "", `["web","go"]`, "api", "", "3", ""},
+		{"true", "data", "eu", "", "db", "", "1", ""},
+		{"", "", "", "", "cache", "80", "", "1500000"},
+	})
+}
+
+func TestMapSpecDottedKey(t *testing.T) {
+	is := is.New(t)
+	maps := decodeMaps(is, `[{"a.b": 1, "a": {"b": "x"}}]`)
+	spec := MapSpec(maps, nil)
+	is.Equal(columnTitles(spec.Columns), []string{"a.b", "a.b"})
+	is.Equal(len(spec.ColumnByName), 2)
+	tab := NewTable(spec)
+	tab.SetColor(false)
+	row, err := tab.FormatItem(MapItems(maps)[0])
+	is.NotErr(err)
+	is.Equal(row, []string{"x", "1"})
+	is.Equal(tab.ColumnByName["a.b"].Getter, MapGetter{Keys: []string{"a", "b"}})
+	is.Equal(tab.ColumnByName["a.b_2"].Getter, MapGetter{Keys: []string{"a.b"}})
+}
+
+func TestMapGetter(t *testing.T) {
+	is := is.New(t)
+	maps := decodeMaps(is, testMapsJSON)
+	g := NewMapGetter("meta.owner")
+	value, err := g.Value(maps[1])
+	is.NotErr(err)
+	is.Equal(value, "data")
+	// path through a non-map value
+	value, err = NewMapGetter("name.first").Value(maps[0])
+	is.NotErr(err)
+	is.Equal(value, nil)
+	str, err := NewMapGetter("meta").ValueString("meta", maps[0])
+	is.NotErr(err)
+	is.Equal(str, `{"owner":"ops","tags":["web","go"]}`)
+	_, err = g.Value([]any{"x"})
+	is.Err(err)
+}
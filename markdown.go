@@ -0,0 +1,135 @@
+package table
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`|`, `\|`,
+	"\n", "<br>",
+)
+
+func markdownLinkEscape(url string) string {
+	return strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29", "|", "%7C").Replace(url)
+}
+
+// markdownCell formats the cell of col for item, as escaped Markdown text
+// and a native link if col.Getter is a LinkGetter or the formatted value
+// contains an OSC 8 hyperlink
+func (t *Table) markdownCell(col *Column, item any) (string, error) {
+	value, err := col.Getter.Value(item)
+	if err != nil {
+		return "", err
+	}
+	formatted, err := col.Getter.Format(item, value)
+	if err != nil {
+		return "", err
+	}
+	link, err := columnLink(col, item)
+	if err != nil {
+		return "", err
+	}
+	url, text := linkTarget(formatted)
+	if link == "" {
+		link = url
+	}
+	text = markdownEscaper.Replace(StripANSI(text))
+	if link == "" {
+		return text, nil
+	}
+	text = strings.NewReplacer("[", `\[`, "]", `\]`).Replace(text)
+	return "[" + text + "](" + markdownLinkEscape(link) + ")", nil
+}
+
+func markdownAlignRule(al Alignment, width uint16) string {
+	switch alignmentName(al) {
+	case "left":
+		return ":" + strings.Repeat("-", int(width)-1)
+	case "right":
+		return strings.Repeat("-", int(width)-1) + ":"
+	case "center":
+		return ":" + strings.Repeat("-", int(width)-2) + ":"
+	}
+	return strings.Repeat("-", int(width))
+}
+
+// WriteMarkdown writes items as a GitHub Flavored Markdown (pipe) table,
+// with Column.Alignment in the delimiter row and links as native links.
+// Pipe tables have no spans, so titles of HeaderRows are prepended to
+// column titles (like "Disk / used") and cells covered by a cell span
+// are empty
+func (t *Table) WriteMarkdown(out io.Writer, items []any) error {
+	colN := t.ColumnCount()
+	levels, err := t.headerGroupIndex()
+	if err != nil {
+		return err
+	}
+	grid, err := t.spanGrid(len(items))
+	if err != nil {
+		return err
+	}
+	rows := make([][]string, 0, len(items)+1)
+	header := make([]string, colN)
+	for i, col := range t.Columns {
+		title := col.Title
+		for levelI := len(levels) - 1; levelI >= 0; levelI-- {
+			if groupI := levels[levelI][i]; groupI >= 0 {
+				title = t.HeaderRows[levelI][groupI].Title + " / " + title
+			}
+		}
+		header[i] = markdownEscaper.Replace(title)
+	}
+	rows = append(rows, header)
+	for itemI, item := range items {
+		row := make([]string, colN)
+		for i, col := range t.Columns {
+			if grid.owner[itemI][i] != (cellPos{row: itemI, col: i}) {
+				continue
+			}
+			cell, err := t.markdownCell(col, item)
+			if err != nil {
+				return err
+			}
+			row[i] = cell
+		}
+		rows = append(rows, row)
+	}
+	widths := make([]uint16, colN)
+	for i := range widths {
+		// minimum width of delimiter row cells
+		widths[i] = 3
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if w := visualWidth(cell); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+	writeRow := func(cells []string) error {
+		_, err := fmt.Fprintln(out, "| "+strings.Join(cells, " | ")+" |")
+		return err
+	}
+	for rowI, row := range rows {
+		cells := make([]string, colN)
+		for i, cell := range row {
+			cells[i] = AlignmentLeft(cell, widths[i])
+		}
+		if err := writeRow(cells); err != nil {
+			return err
+		}
+		if rowI > 0 {
+			continue
+		}
+		for i, col := range t.Columns {
+			cells[i] = markdownAlignRule(col.Alignment, widths[i])
+		}
+		if err := writeRow(cells); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package table
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+var markupTestRows = [][]any{
+	{"さの.png", 12},
+	{"", 1024},
+	{"a|b", 5},
+}
+
+func TestWriteRST(t *testing.T) {
+	is := is.New(t)
+	tab, items := newTestTableItems([]string{"name", "size"}, markupTestRows...)
+	tab.ColumnByName["size"].Alignment = AlignmentRight
+	out := &strings.Builder{}
+	is.NotErr(tab.WriteRSTGrid(out, items))
+	is.Equal(out.String(), `+----------+------+
+| name     | size |
++==========+======+
+| さの.png |   12 |
++----------+------+
+|          | 1024 |
++----------+------+
+| a\|b     |    5 |
++----------+------+
+`)
+
+	out.Reset()
+	is.NotErr(tab.WriteRSTSimple(out, items))
+	is.Equal(out.String(), `========  ====
+name      size
+========  ====
+さの.png    12
+\         1024
+a\|b         5
+========  ====
+`)
+}
+
+func TestWriteAsciiDoc(t *testing.T) {
+	is := is.New(t)
+	tab, items := newTestTableItems([]string{"name", "size"}, markupTestRows...)
+	tab.ColumnByName["size"].Alignment = AlignmentRight
+	out := &strings.Builder{}
+	is.NotErr(tab.WriteAsciiDoc(out, items))
+	is.Equal(out.String(), `[cols="<,>",options="header"]
+|===
+| name     | size
+| さの.png |   12
+|          | 1024
+| a\|b     |    5
+|===
+`)
+}
+
+func TestWriteOrgMode(t *testing.T) {
+	is := is.New(t)
+	tab, items := newTestTableItems([]string{"name", "size"}, markupTestRows...)
+	tab.ColumnByName["size"].Alignment = AlignmentRight
+	out := &strings.Builder{}
+	is.NotErr(tab.WriteOrgMode(out, items[:2]))
+	is.Equal(out.String(), `| name     | size |
+|----------+------|
+| さの.png |   12 |
+|          | 1024 |
+`)
+}
+
+func TestWriteWiki(t *testing.T) {
+	is := is.New(t)
+	tab, items := newTestTableItems([]string{"name", "size"}, markupTestRows...)
+	tab.ColumnByName["size"].Alignment = AlignmentRight
+	items = append(items, []any{"{x}<y>", 0})
+	out := &strings.Builder{}
+	is.NotErr(tab.WriteMediaWiki(out, items[1:]))
+	is.Equal(out.String(), `{| class="wikitable"
+|-
+! name
+! style="text-align: right;" | size
+|-
+|
+| style="text-align: right;" | 1024
+|-
+| a&#124;b
+| style="text-align: right;" | 5
+|-
+| &#123;x&#125;&lt;y&gt;
+| style="text-align: right;" | 0
+|}
+`)
+
+	out.Reset()
+	is.NotErr(tab.WriteJira(out, items[1:]))
+	is.Equal(out.String(), `||name||size||
+| |1024|
+|a\|b|5|
+|\{x\}<y>|0|
+`)
+
+	out.Reset()
+	is.NotErr(tab.WriteConfluence(out, items[2:]))
+	is.Equal(out.String(), `<table><tbody>
+<tr><th>name</th><th style="text-align: right;">size</th></tr>
+<tr><td>a|b</td><td style="text-align: right;">5</td></tr>
+<tr><td>{x}&lt;y&gt;</td><td style="text-align: right;">0</td></tr>
+</tbody></table>
+`)
+}
@@ -0,0 +1,277 @@
+package table
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const odsMimeType = "application/vnd.oasis.opendocument.spreadsheet"
+
+const odsNamespaces = `xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" ` +
+	`xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0" ` +
+	`xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0" ` +
+	`xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" ` +
+	`xmlns:number="urn:oasis:names:tc:opendocument:xmlns:datastyle:1.0" ` +
+	`xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0" ` +
+	`office:version="1.2"`
+
+var odsLayoutElements = map[layoutElemKind]string{
+	layoutYear:         `<number:year number:style="long"/>`,
+	layoutYear2:        `<number:year/>`,
+	layoutMonthLong:    `<number:month number:textual="true" number:style="long"/>`,
+	layoutMonthShort:   `<number:month number:textual="true"/>`,
+	layoutMonth2:       `<number:month number:style="long"/>`,
+	layoutMonth:        `<number:month/>`,
+	layoutDay2:         `<number:day number:style="long"/>`,
+	layoutDay:          `<number:day/>`,
+	layoutWeekdayLong:  `<number:day-of-week number:style="long"/>`,
+	layoutWeekdayShort: `<number:day-of-week/>`,
+	layoutHour24:       `<number:hours number:style="long"/>`,
+	layoutHour12Pad:    `<number:hours number:style="long"/>`,
+	layoutHour12:       `<number:hours/>`,
+	layoutMinute2:      `<number:minutes number:style="long"/>`,
+	layoutSecond2:      `<number:seconds number:style="long"/>`,
+	layoutPM:           `<number:am-pm/>`,
+	layoutPMLower:      `<number:am-pm/>`,
+}
+
+const defaultODSTimeLayout = "2006-01-02 15:04:05"
+
+// odsDateStyle returns a number:date-style element for a Go time layout,
+// time zone and fraction of second elements are dropped
+func odsDateStyle(name string, layout string) string {
+	if layout == "" {
+		layout = defaultODSTimeLayout
+	}
+	var sb strings.Builder
+	sb.WriteString(`<number:date-style style:name="` + name + `">`)
+	for _, elem := range splitTimeLayout(layout) {
+		if elem.kind == layoutLiteral {
+			sb.WriteString("<number:text>" + xmlEscape(elem.literal) + "</number:text>")
+			continue
+		}
+		sb.WriteString(odsLayoutElements[elem.kind])
+	}
+	sb.WriteString("</number:date-style>")
+	return sb.String()
+}
+
+var odsAlignments = []string{"", "left", "right", "center"}
+
+// odsTextAlign maps alignmentName to fo:text-align
+var odsTextAlign = map[string]string{
+	"left":   "start",
+	"right":  "end",
+	"center": "center",
+}
+
+// odsCellStyleName returns name of the cell style for sheet sheetI,
+// alignment name align and cell kind
+func odsCellStyleName(sheetI int, align string, kind sheetCellKind) string {
+	name := "ce" + strconv.Itoa(sheetI)
+	if align != "" {
+		name += "-" + align
+	}
+	switch kind {
+	case sheetTime:
+		name += "-date"
+	case sheetDuration:
+		name += "-time"
+	}
+	return name
+}
+
+// odsAutomaticStyles returns automatic styles: header style, one date style
+// per sheet and cell styles with paragraph alignment from Column.Alignment
+func odsAutomaticStyles(sheets []*Sheet) string {
+	var sb strings.Builder
+	sb.WriteString("<office:automatic-styles>")
+	sb.WriteString(`<number:time-style style:name="Ntime" number:truncate-on-overflow="false">` +
+		`<number:hours/><number:text>:</number:text>` +
+		`<number:minutes number:style="long"/><number:text>:</number:text>` +
+		`<number:seconds number:style="long"/></number:time-style>`)
+	sb.WriteString(`<style:style style:name="ce-header" style:family="table-cell">` +
+		`<style:text-properties fo:font-weight="bold"/></style:style>`)
+	for sheetI, sheet := range sheets {
+		dateStyle := "Ndate" + strconv.Itoa(sheetI)
+		sb.WriteString(odsDateStyle(dateStyle, sheet.Table.TimeFormat))
+		for _, align := range odsAlignments {
+			for _, kind := range []sheetCellKind{sheetString, sheetTime, sheetDuration} {
+				attrs := ""
+				switch kind {
+				case sheetTime:
+					attrs = ` style:data-style-name="` + dateStyle + `"`
+				case sheetDuration:
+					attrs = ` style:data-style-name="Ntime"`
+				}
+				sb.WriteString(`<style:style style:name="` + odsCellStyleName(sheetI, align, kind) +
+					`" style:family="table-cell"` + attrs + `>`)
+				if align != "" {
+					sb.WriteString(`<style:paragraph-properties fo:text-align="` + odsTextAlign[align] + `"/>`)
+				}
+				sb.WriteString("</style:style>")
+			}
+		}
+	}
+	sb.WriteString("</office:automatic-styles>")
+	return sb.String()
+}
+
+// odsDuration formats d as an ISO 8601 duration, like PT36H05M00S
+func odsDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	hours := d / time.Hour
+	minutes := (d % time.Hour) / time.Minute
+	seconds := float64(d%time.Minute) / float64(time.Second)
+	return fmt.Sprintf("%sPT%02dH%02dM%sS", sign, hours, minutes, strconv.FormatFloat(seconds, 'f', -1, 64))
+}
+
+func odsCell(cell sheetCell, styleName string, display string) string {
+	attrs := ""
+	switch cell.kind {
+	case sheetEmpty:
+		return `<table:table-cell table:style-name="` + styleName + `"/>`
+	case sheetString:
+		attrs = `office:value-type="string"`
+	case sheetNumber:
+		attrs = `office:value-type="float" office:value="` + strconv.FormatFloat(cell.num, 'g', -1, 64) + `"`
+	case sheetBool:
+		attrs = `office:value-type="boolean" office:boolean-value="` + strconv.FormatBool(cell.boolean) + `"`
+	case sheetTime:
+		attrs = `office:value-type="date" office:date-value="` + cell.time.Format("2006-01-02T15:04:05.999999999") + `"`
+	case sheetDuration:
+		attrs = `office:value-type="time" office:time-value="` + odsDuration(cell.duration) + `"`
+	}
+	return `<table:table-cell table:style-name="` + styleName + `" ` + attrs + `>` +
+		`<text:p>` + xmlEscape(display) + `</text:p></table:table-cell>`
+}
+
+// odsTable returns a table:table element of sheet
+func odsTable(sheet *Sheet, sheetI int) (string, error) {
+	t := sheet.Table
+	var sb strings.Builder
+	sb.WriteString(`<table:table table:name="` + xmlEscape(sheetName(sheet.Name, sheetI)) + `">`)
+	sb.WriteString(`<table:table-column table:number-columns-repeated="` + strconv.Itoa(t.ColumnCount()) + `"/>`)
+	sb.WriteString("<table:table-header-rows><table:table-row>")
+	for _, col := range t.Columns {
+		sb.WriteString(`<table:table-cell table:style-name="ce-header" office:value-type="string">` +
+			`<text:p>` + xmlEscape(col.Title) + `</text:p></table:table-cell>`)
+	}
+	sb.WriteString("</table:table-row></table:table-header-rows>")
+	for _, item := range sheet.Items {
+		cells, err := t.sheetRow(item)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString("<table:table-row>")
+		for colI, col := range t.Columns {
+			cell := cells[colI]
+			display := cell.str
+			if cell.kind != sheetString && cell.kind != sheetEmpty {
+				value, err := col.Getter.Value(item)
+				if err != nil {
+					return "", err
+				}
+				formatted, err := col.Getter.Format(item, value)
+				if err != nil {
+					return "", err
+				}
+				display = StripANSI(formatted)
+			}
+			styleName := odsCellStyleName(sheetI, alignmentName(col.Alignment), cell.kind)
+			sb.WriteString(odsCell(cell, styleName, display))
+		}
+		sb.WriteString("</table:table-row>")
+	}
+	sb.WriteString("</table:table>")
+	return sb.String(), nil
+}
+
+// odsBody returns the office:body element with one table per sheet
+func odsBody(sheets []*Sheet) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("<office:body><office:spreadsheet>")
+	for sheetI, sheet := range sheets {
+		tableXML, err := odsTable(sheet, sheetI)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(tableXML)
+	}
+	sb.WriteString("</office:spreadsheet></office:body>")
+	return sb.String(), nil
+}
+
+// WriteODS writes an OpenDocument Spreadsheet (zip) with one table per
+// Sheet. Cells are typed from Getter.Value, dates use a format derived
+// from TableSpec.TimeFormat and the header is a repeated (bold) header row
+func WriteODS(out io.Writer, sheets ...*Sheet) error {
+	if len(sheets) == 0 {
+		return fmt.Errorf("no sheets")
+	}
+	body, err := odsBody(sheets)
+	if err != nil {
+		return err
+	}
+	content := xml.Header + `<office:document-content ` + odsNamespaces + `>` +
+		odsAutomaticStyles(sheets) + body + `</office:document-content>`
+	manifest := xml.Header +
+		`<manifest:manifest xmlns:manifest="urn:oasis:names:tc:opendocument:xmlns:manifest:1.0" manifest:version="1.2">` +
+		`<manifest:file-entry manifest:full-path="/" manifest:version="1.2" manifest:media-type="` + odsMimeType + `"/>` +
+		`<manifest:file-entry manifest:full-path="content.xml" manifest:media-type="text/xml"/>` +
+		`</manifest:manifest>`
+	zw := zip.NewWriter(out)
+	// mimetype must be the first file, and not compressed
+	w, err := zw.CreateHeader(&zip.FileHeader{
+		Name:   "mimetype",
+		Method: zip.Store,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, odsMimeType); err != nil {
+		return err
+	}
+	if err := writeZipFile(zw, "META-INF/manifest.xml", manifest); err != nil {
+		return err
+	}
+	if err := writeZipFile(zw, "content.xml", content); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// WriteFODS writes a flat (single XML file) OpenDocument Spreadsheet,
+// see WriteODS
+func WriteFODS(out io.Writer, sheets ...*Sheet) error {
+	if len(sheets) == 0 {
+		return fmt.Errorf("no sheets")
+	}
+	body, err := odsBody(sheets)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, xml.Header+
+		`<office:document `+odsNamespaces+` office:mimetype="`+odsMimeType+`">`+
+		odsAutomaticStyles(sheets)+body+"</office:document>\n")
+	return err
+}
+
+// WriteODS writes items as a single table OpenDocument Spreadsheet
+func (t *Table) WriteODS(out io.Writer, items []any, sheetName string) error {
+	return WriteODS(out, &Sheet{Table: t, Name: sheetName, Items: items})
+}
+
+// WriteFODS writes items as a single table flat OpenDocument Spreadsheet
+func (t *Table) WriteFODS(out io.Writer, items []any, sheetName string) error {
+	return WriteFODS(out, &Sheet{Table: t, Name: sheetName, Items: items})
+}
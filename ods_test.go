@@ -0,0 +1,91 @@
+package table
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/ilius/is/v2"
+)
+
+type odsTestCell struct {
+	Style     string `xml:"style-name,attr"`
+	ValueType string `xml:"value-type,attr"`
+	Value     string `xml:"value,attr"`
+	DateValue string `xml:"date-value,attr"`
+	TimeValue string `xml:"time-value,attr"`
+	BoolValue string `xml:"boolean-value,attr"`
+	Text      string `xml:"p"`
+}
+
+type odsTestTable struct {
+	Name   string `xml:"name,attr"`
+	Header []struct {
+		Cells []odsTestCell `xml:"table-cell"`
+	} `xml:"table-header-rows>table-row"`
+	Rows []struct {
+		Cells []odsTestCell `xml:"table-cell"`
+	} `xml:"table-row"`
+}
+
+type odsTestDoc struct {
+	Tables []odsTestTable `xml:"body>spreadsheet>table"`
+}
+
+var odsTestRows = [][]any{
+	{"a<b", 12, true, time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC), 36*time.Hour + 5*time.Minute},
+}
+
+func checkODSDoc(is *is.Is, doc odsTestDoc) {
+	is.Equal(len(doc.Tables), 1)
+	table := doc.Tables[0]
+	is.Equal(table.Name, "files")
+	is.Equal(len(table.Header), 1)
+	is.Equal(table.Header[0].Cells[0].Text, "name")
+	is.Equal(table.Header[0].Cells[0].Style, "ce-header")
+	is.Equal(len(table.Rows), 1)
+	cells := table.Rows[0].Cells
+	is.Equal(cells[0], odsTestCell{Style: "ce0", ValueType: "string", Text: "a<b"})
+	is.Equal(cells[1], odsTestCell{Style: "ce0-right", ValueType: "float", Value: "12", Text: "12"})
+	is.Equal(cells[2].BoolValue, "true")
+	is.Equal(cells[3].DateValue, "2023-01-02T12:00:00")
+	is.Equal(cells[3].Style, "ce0-date")
+	is.Equal(cells[4].TimeValue, "PT36H05M0S")
+}
+
+func TestWriteODS(t *testing.T) {
+	is := is.New(t)
+	tab, items := newTestTableItems([]string{"name", "size", "ok", "mtime", "age"}, odsTestRows...)
+	tab.ColumnByName["size"].Alignment = AlignmentRight
+	tab.TimeFormat = "2006-01-02"
+	buf := &bytes.Buffer{}
+	is.NotErr(tab.WriteODS(buf, items, "files"))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	is.NotErr(err)
+	is.Equal(zr.File[0].Name, "mimetype")
+	is.Equal(zr.File[0].Method, zip.Store)
+	is.Equal(string(readZipFile(is, zr, "mimetype")), odsMimeType)
+	content := readZipFile(is, zr, "content.xml")
+	is.True(bytes.Contains(content, []byte(`<number:date-style style:name="Ndate0">`+
+		`<number:year number:style="long"/><number:text>-</number:text>`+
+		`<number:month number:style="long"/><number:text>-</number:text>`+
+		`<number:day number:style="long"/></number:date-style>`)))
+	doc := odsTestDoc{}
+	is.NotErr(xml.Unmarshal(content, &doc))
+	checkODSDoc(is, doc)
+}
+
+func TestWriteFODS(t *testing.T) {
+	is := is.New(t)
+	tab, items := newTestTableItems([]string{"name", "size", "ok", "mtime", "age"}, odsTestRows...)
+	tab.ColumnByName["size"].Alignment = AlignmentRight
+	tab.TimeFormat = "2006-01-02"
+	buf := &bytes.Buffer{}
+	is.NotErr(tab.WriteFODS(buf, items, "files"))
+	doc := odsTestDoc{}
+	is.NotErr(xml.Unmarshal(buf.Bytes(), &doc))
+	checkODSDoc(is, doc)
+}
@@ -0,0 +1,36 @@
+package table
+
+import (
+	"io"
+	"strings"
+)
+
+var orgEscaper = strings.NewReplacer("|", `\vert{}`)
+
+// WriteOrgMode writes items as an Org-mode table, header is separated
+// by a |---+---| rule
+func (t *Table) WriteOrgMode(out io.Writer, items []any) error {
+	header, rows, widths, err := t.textCells(items, orgEscaper.Replace)
+	if err != nil {
+		return err
+	}
+	parts := make([]string, len(widths))
+	for i, w := range widths {
+		parts[i] = strings.Repeat("-", int(w)+2)
+	}
+	row := func(cells []string) string {
+		return "| " + strings.Join(cells, " | ") + " |"
+	}
+	for i, cell := range header {
+		header[i] = AlignmentLeft(cell, widths[i])
+	}
+	lines := []string{
+		row(header),
+		"|" + strings.Join(parts, "+") + "|",
+	}
+	for _, cells := range rows {
+		lines = append(lines, row(t.alignRow(cells, widths)))
+	}
+	_, err = io.WriteString(out, strings.Join(lines, "\n")+"\n")
+	return err
+}
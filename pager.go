@@ -0,0 +1,685 @@
+package table
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// pagerKeySep separates frozen key columns from scrolled columns
+const pagerKeySep = " │ "
+
+type pagerKeyKind uint8
+
+const (
+	keyRune pagerKeyKind = iota
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyPageUp
+	keyPageDown
+	keyHome
+	keyEnd
+	keyEnter
+	keyEscape
+	keyBackspace
+	keyInterrupt
+	keyUnknown
+)
+
+type pagerKey struct {
+	kind pagerKeyKind
+	r    rune
+}
+
+// pagerEscapeKeys are escape sequences of special keys, without ESC
+var pagerEscapeKeys = map[string]pagerKeyKind{
+	"[A":  keyUp,
+	"[B":  keyDown,
+	"[C":  keyRight,
+	"[D":  keyLeft,
+	"OA":  keyUp,
+	"OB":  keyDown,
+	"OC":  keyRight,
+	"OD":  keyLeft,
+	"[5~": keyPageUp,
+	"[6~": keyPageDown,
+	"[H":  keyHome,
+	"[F":  keyEnd,
+	"OH":  keyHome,
+	"OF":  keyEnd,
+	"[1~": keyHome,
+	"[4~": keyEnd,
+	"[7~": keyHome,
+	"[8~": keyEnd,
+}
+
+// parsePagerKeys decodes keys from terminal input, ESC that does not
+// start a known key sequence is the Escape key
+func parsePagerKeys(input string) []pagerKey {
+	keys := []pagerKey{}
+	for input != "" {
+		c := input[0]
+		switch {
+		case c == 0x1b:
+			seq := ""
+			switch {
+			case len(input) > 2 && input[1] == '[':
+				end := 2
+				for end < len(input) && input[end] >= 0x20 && input[end] <= 0x3f {
+					end++
+				}
+				if end == len(input) {
+					end--
+				}
+				seq = input[1 : end+1]
+			case len(input) > 2 && input[1] == 'O':
+				seq = input[1:3]
+			}
+			kind, ok := pagerEscapeKeys[seq]
+			switch {
+			case ok:
+			case seq == "":
+				// a lone ESC, or ESC followed by a key like Alt+key
+				kind = keyEscape
+			default:
+				kind = keyUnknown
+			}
+			keys = append(keys, pagerKey{kind: kind})
+			input = input[1+len(seq):]
+			continue
+		case c == '\r' || c == '\n':
+			keys = append(keys, pagerKey{kind: keyEnter})
+		case c == 0x7f || c == 0x08:
+			keys = append(keys, pagerKey{kind: keyBackspace})
+		case c == 0x03:
+			keys = append(keys, pagerKey{kind: keyInterrupt})
+		case c < 0x20:
+			keys = append(keys, pagerKey{kind: keyUnknown})
+		default:
+			r, size := utf8.DecodeRuneInString(input)
+			keys = append(keys, pagerKey{kind: keyRune, r: r})
+			input = input[size:]
+			continue
+		}
+		input = input[1:]
+	}
+	return keys
+}
+
+type pagerPrompt uint8
+
+const (
+	promptNone pagerPrompt = iota
+	promptSearch
+	promptColumn
+)
+
+// Pager is an interactive terminal viewer of table items, with a frozen
+// header and frozen key columns on the left. Keys are:
+//
+//	j, k, Down, Up          scroll one row
+//	Space, b, PgDn, PgUp    scroll one page
+//	g, G, Home, End         go to the first or last row
+//	h, l, Left, Right       scroll one column
+//	/                       search (incremental), n and N go to the next
+//	                        and previous match
+//	c                       jump to a column by name (or title) prefix
+//	s                       sort by the current column: ascending,
+//	                        descending, then original order
+//	q, Ctrl+C               quit
+//
+// The current column is the first column after key columns
+type Pager struct {
+	table *Table
+	items []any
+	rows  [][]string
+	// values are used for sorting, computed on first sort
+	values [][]any
+
+	// KeyColumns is the number of frozen columns on the left
+	KeyColumns int
+	// Sep is the column separator, default is two spaces
+	Sep string
+
+	width  int
+	height int
+
+	order  []int
+	top    int
+	left   int
+	cursor int
+
+	prompt      pagerPrompt
+	input       string
+	search      string
+	message     string
+	sortColumn  int
+	sortReverse bool
+}
+
+// NewPager formats items with t.FormatItem, column widths of t are updated
+func NewPager(t *Table, items []any) (*Pager, error) {
+	p := &Pager{
+		table:      t,
+		items:      items,
+		rows:       make([][]string, len(items)),
+		Sep:        "  ",
+		width:      80,
+		height:     24,
+		order:      make([]int, len(items)),
+		cursor:     -1,
+		sortColumn: -1,
+	}
+	for i, item := range items {
+		row, err := t.FormatItem(item)
+		if err != nil {
+			return nil, err
+		}
+		p.rows[i] = row
+		p.order[i] = i
+	}
+	widths := map[string]uint16{}
+	for _, col := range t.Columns {
+		widths[col.Name] = visualWidth(col.Title)
+	}
+	t.UpdateWidth(widths)
+	return p, nil
+}
+
+// SetSize sets size of the screen, Run sets it from the terminal
+func (p *Pager) SetSize(width int, height int) {
+	p.width, p.height = width, height
+	p.clamp()
+}
+
+// pageSize is the number of rows shown, without header and status lines
+func (p *Pager) pageSize() int {
+	if p.height < 3 {
+		return 1
+	}
+	return p.height - 2
+}
+
+func (p *Pager) keyColumns() int {
+	if p.KeyColumns > p.table.ColumnCount() {
+		return p.table.ColumnCount()
+	}
+	if p.KeyColumns < 0 {
+		return 0
+	}
+	return p.KeyColumns
+}
+
+// clamp keeps top and left in range
+func (p *Pager) clamp() {
+	if maxTop := len(p.rows) - p.pageSize(); p.top > maxTop {
+		p.top = maxTop
+	}
+	if p.top < 0 {
+		p.top = 0
+	}
+	if maxLeft := p.table.ColumnCount() - p.keyColumns() - 1; p.left > maxLeft {
+		p.left = maxLeft
+	}
+	if p.left < 0 {
+		p.left = 0
+	}
+}
+
+// currentColumn returns index of the current column, or -1
+func (p *Pager) currentColumn() int {
+	colI := p.keyColumns() + p.left
+	if colI >= p.table.ColumnCount() {
+		return -1
+	}
+	return colI
+}
+
+// Update handles terminal input, and returns true if the pager should quit
+func (p *Pager) Update(input []byte) bool {
+	for _, key := range parsePagerKeys(string(input)) {
+		if p.handleKey(key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Pager) handleKey(key pagerKey) bool {
+	if key.kind == keyInterrupt {
+		return true
+	}
+	if p.prompt != promptNone {
+		p.handlePromptKey(key)
+		return false
+	}
+	p.message = ""
+	page := p.pageSize()
+	switch key.kind {
+	case keyUp:
+		p.top--
+	case keyDown:
+		p.top++
+	case keyPageUp:
+		p.top -= page
+	case keyPageDown:
+		p.top += page
+	case keyHome:
+		p.top = 0
+	case keyEnd:
+		p.top = len(p.rows)
+	case keyLeft:
+		p.left--
+	case keyRight:
+		p.left++
+	case keyRune:
+		switch key.r {
+		case 'q':
+			return true
+		case 'k':
+			p.top--
+		case 'j':
+			p.top++
+		case 'b':
+			p.top -= page
+		case ' ':
+			p.top += page
+		case 'g':
+			p.top = 0
+		case 'G':
+			p.top = len(p.rows)
+		case 'h':
+			p.left--
+		case 'l':
+			p.left++
+		case '/':
+			p.prompt, p.input = promptSearch, ""
+		case 'c':
+			p.prompt, p.input = promptColumn, ""
+		case 'n':
+			p.findMatch(p.cursor+1, 1)
+		case 'N':
+			p.findMatch(p.cursor-1, -1)
+		case 's':
+			p.toggleSort()
+		}
+	}
+	p.clamp()
+	return false
+}
+
+func (p *Pager) handlePromptKey(key pagerKey) {
+	switch key.kind {
+	case keyEscape:
+		if p.prompt == promptSearch {
+			p.search = ""
+			p.cursor = -1
+		}
+		p.prompt = promptNone
+		return
+	case keyEnter:
+		prompt := p.prompt
+		p.prompt = promptNone
+		if prompt == promptColumn {
+			p.jumpToColumn(p.input)
+		}
+		return
+	case keyBackspace:
+		if p.input != "" {
+			runes := []rune(p.input)
+			p.input = string(runes[:len(runes)-1])
+		}
+	case keyRune:
+		p.input += string(key.r)
+	default:
+		return
+	}
+	if p.prompt == promptSearch {
+		// incremental search from the current match or first shown row
+		p.search = p.input
+		start := p.top
+		if p.cursor >= 0 {
+			start = p.cursor
+		}
+		p.findMatch(start, 1)
+	}
+}
+
+// rowMatches returns true if row (index in order) contains the search text
+func (p *Pager) rowMatches(orderI int) bool {
+	query := strings.ToLower(p.search)
+	for _, cell := range p.rows[p.order[orderI]] {
+		if strings.Contains(strings.ToLower(StripANSI(cell)), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// findMatch moves the cursor to the first row matching the search,
+// starting at row start in direction step and wrapping around
+func (p *Pager) findMatch(start int, step int) {
+	n := len(p.rows)
+	if p.search == "" || n == 0 {
+		return
+	}
+	for i := 0; i < n; i++ {
+		orderI := ((start+i*step)%n + n) % n
+		if p.rowMatches(orderI) {
+			p.cursor = orderI
+			if orderI < p.top || orderI >= p.top+p.pageSize() {
+				p.top = orderI
+			}
+			p.clamp()
+			return
+		}
+	}
+	p.cursor = -1
+	p.message = "not found: " + p.search
+}
+
+// jumpToColumn scrolls to the first column whose name or title starts
+// with prefix (case-insensitive)
+func (p *Pager) jumpToColumn(prefix string) {
+	prefix = strings.ToLower(prefix)
+	for colI, col := range p.table.Columns {
+		if !strings.HasPrefix(strings.ToLower(col.Name), prefix) &&
+			!strings.HasPrefix(strings.ToLower(col.Title), prefix) {
+			continue
+		}
+		if colI >= p.keyColumns() {
+			p.left = colI - p.keyColumns()
+		}
+		p.clamp()
+		return
+	}
+	p.message = "no column: " + prefix
+}
+
+// toggleSort sorts rows by the current column, ascending, then descending,
+// then in the original order. Values are compared by CompareValues
+func (p *Pager) toggleSort() {
+	colI := p.currentColumn()
+	if colI < 0 {
+		return
+	}
+	switch {
+	case p.sortColumn != colI:
+		p.sortColumn, p.sortReverse = colI, false
+	case !p.sortReverse:
+		p.sortReverse = true
+	default:
+		p.sortColumn = -1
+	}
+	if p.values == nil {
+		p.values = make([][]any, len(p.items))
+		for i, item := range p.items {
+			p.values[i] = make([]any, p.table.ColumnCount())
+			for c, col := range p.table.Columns {
+				value, err := col.Getter.Value(item)
+				if err != nil {
+					p.message = err.Error()
+					continue
+				}
+				p.values[i][c] = value
+			}
+		}
+	}
+	for i := range p.order {
+		p.order[i] = i
+	}
+	if p.sortColumn >= 0 {
+		sort.SliceStable(p.order, func(i, j int) bool {
+			cmp := CompareValues(p.values[p.order[i]][colI], p.values[p.order[j]][colI])
+			if p.sortReverse {
+				return cmp > 0
+			}
+			return cmp < 0
+		})
+	}
+	p.cursor = -1
+	p.findMatch(0, 1)
+}
+
+// highlight shows matches of the search in cell as inverse text,
+// escape sequences of cell are kept
+func (p *Pager) highlight(cell string) string {
+	if p.search == "" {
+		return cell
+	}
+	plain := StripANSI(cell)
+	lower := strings.ToLower(plain)
+	query := strings.ToLower(p.search)
+	if len(lower) != len(plain) || !strings.Contains(lower, query) {
+		return cell
+	}
+	// starts and ends of matches, as offsets in plain
+	starts, ends := map[int]bool{}, map[int]bool{}
+	for offset := 0; ; {
+		i := strings.Index(lower[offset:], query)
+		if i < 0 {
+			break
+		}
+		starts[offset+i] = true
+		ends[offset+i+len(query)] = true
+		offset += i + len(query)
+	}
+	var sb strings.Builder
+	pos := 0
+	inMatch := false
+	ForEachToken(cell, func(tok Token) {
+		if tok.Kind != TokenText {
+			sb.WriteString(tok.Text)
+			if inMatch {
+				// the sequence may have reset inverse video
+				sb.WriteString("\x1b[7m")
+			}
+			return
+		}
+		for i := 0; i < len(tok.Text); i++ {
+			if starts[pos] {
+				sb.WriteString("\x1b[7m")
+				inMatch = true
+			}
+			sb.WriteByte(tok.Text[i])
+			pos++
+			if ends[pos] && inMatch {
+				sb.WriteString("\x1b[27m")
+				inMatch = false
+			}
+		}
+	})
+	return sb.String()
+}
+
+// line joins key columns and columns starting at the current column,
+// truncated to the screen width
+func (p *Pager) line(cells []string) string {
+	keyN := p.keyColumns()
+	parts := make([]string, 0, len(cells))
+	for colI, cell := range cells {
+		if colI >= keyN && colI < keyN+p.left {
+			continue
+		}
+		parts = append(parts, cell)
+	}
+	line := ""
+	if keyN > 0 {
+		line = strings.Join(parts[:keyN], p.Sep)
+		if len(parts) > keyN {
+			line += pagerKeySep
+		}
+		parts = parts[keyN:]
+	}
+	line += strings.Join(parts, p.Sep)
+	if p.width > 0 && int(visualWidth(line)) > p.width {
+		line = TruncateWidth(line, uint16(p.width)) + "\x1b[0m"
+	}
+	return line
+}
+
+func (p *Pager) statusLine() string {
+	switch p.prompt {
+	case promptSearch:
+		return "/" + p.search
+	case promptColumn:
+		return "column: " + p.input
+	}
+	n := len(p.rows)
+	status := "no rows"
+	if n > 0 {
+		last := p.top + p.pageSize()
+		if last > n {
+			last = n
+		}
+		status = "rows " + strconv.Itoa(p.top+1) + "-" + strconv.Itoa(last) + " of " + strconv.Itoa(n)
+	}
+	if colI := p.currentColumn(); colI >= 0 {
+		status += "  column: " + p.table.Columns[colI].Name
+	}
+	if p.sortColumn >= 0 {
+		arrow := "↑"
+		if p.sortReverse {
+			arrow = "↓"
+		}
+		status += "  sort: " + p.table.Columns[p.sortColumn].Name + " " + arrow
+	}
+	if p.search != "" {
+		status += "  /" + p.search
+	}
+	if p.message != "" {
+		status += "  " + p.message
+	}
+	if p.width > 0 {
+		status = TruncateWidth(status, uint16(p.width))
+	}
+	return "\x1b[7m" + status + "\x1b[27m"
+}
+
+// View returns the screen: header, visible rows and the status line,
+// separated by "\r\n" and each line followed by "clear to end of line"
+func (p *Pager) View() string {
+	lines := make([]string, 0, p.height)
+	lines = append(lines, p.line(p.table.headerCells()))
+	for orderI := p.top; orderI < len(p.rows) && orderI < p.top+p.pageSize(); orderI++ {
+		row := p.rows[p.order[orderI]]
+		cells := make([]string, len(row))
+		for colI, col := range p.table.Columns {
+			cells[colI] = p.highlight(p.table.alignCell(colI, row[colI], p.table.Width(col.Name)))
+		}
+		line := p.line(cells)
+		if orderI == p.cursor {
+			line = "\x1b[1m" + line + "\x1b[22m"
+		}
+		lines = append(lines, p.table.styleRow(line, orderI))
+	}
+	for len(lines) < p.height-1 {
+		lines = append(lines, "~")
+	}
+	lines = append(lines, p.statusLine())
+	return strings.Join(lines, "\x1b[K\r\n") + "\x1b[K"
+}
+
+// fileFd returns the descriptor of f, unlike f.Fd it does not put f
+// in blocking mode, so read deadlines of f keep working
+func fileFd(f *os.File) int {
+	fd := -1
+	conn, err := f.SyscallConn()
+	if err != nil {
+		return fd
+	}
+	conn.Control(func(sysFd uintptr) {
+		fd = int(sysFd)
+	})
+	return fd
+}
+
+// pagerInput is the result of one read of terminal input
+type pagerInput struct {
+	data []byte
+	err  error
+}
+
+// readInput reads in and sends the results to inputs until a read fails
+// or done is closed. The last read blocks until input arrives, unless in
+// supports read deadlines
+func readInput(in *os.File, inputs chan<- pagerInput, done <-chan struct{}) {
+	buf := make([]byte, 256)
+	for {
+		n, err := in.Read(buf)
+		select {
+		case inputs <- pagerInput{data: append([]byte{}, buf[:n]...), err: err}:
+		case <-done:
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// Run shows the pager on terminal out and reads keys from terminal in
+// (in raw mode) until q or Ctrl+C is pressed. The alternate screen is
+// used, so the terminal content is restored after quitting. The screen
+// is redrawn when the terminal is resized. Calling in.Fd before Run
+// makes the last read wait for one more key after quitting
+func (p *Pager) Run(in *os.File, out *os.File) error {
+	restore, err := makeRaw(fileFd(in))
+	if err != nil {
+		return err
+	}
+	defer restore()
+	if _, err := io.WriteString(out, "\x1b[?1049h\x1b[?25l"); err != nil {
+		return err
+	}
+	defer io.WriteString(out, "\x1b[?25h\x1b[?1049l")
+	resize := make(chan os.Signal, 1)
+	notifyResize(resize)
+	defer signal.Stop(resize)
+	inputs := make(chan pagerInput)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		readInput(in, inputs, done)
+	}()
+	defer func() {
+		close(done)
+		// interrupt the pending read, so it does not take input after
+		// quitting, if in supports deadlines
+		if in.SetReadDeadline(time.Now()) == nil {
+			<-stopped
+			in.SetReadDeadline(time.Time{})
+		}
+	}()
+	for {
+		if width, height, err := terminalSize(fileFd(out)); err == nil && width > 0 && height > 0 {
+			p.SetSize(width, height)
+		}
+		if _, err := io.WriteString(out, "\x1b[H"+p.View()); err != nil {
+			return err
+		}
+		select {
+		case <-resize:
+			continue
+		case input := <-inputs:
+			if input.err != nil {
+				if input.err == io.EOF {
+					return nil
+				}
+				return fmt.Errorf("error reading terminal input: %w", input.err)
+			}
+			if p.Update(input.data) {
+				return nil
+			}
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package table
+
+import (
+	"bytes"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/ilius/is/v2"
+)
+
+// openPTY opens a pseudo-terminal and returns its master and slave
+func openPTY(t *testing.T) (*os.File, *os.File) {
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR, 0)
+	if err != nil {
+		t.Skip("no pseudo-terminal:", err)
+	}
+	unlock := int32(0)
+	if err := ioctl(int(master.Fd()), syscall.TIOCSPTLCK, unsafe.Pointer(&unlock)); err != nil {
+		master.Close()
+		t.Skip("no pseudo-terminal:", err)
+	}
+	ptyN := uint32(0)
+	if err := ioctl(int(master.Fd()), syscall.TIOCGPTN, unsafe.Pointer(&ptyN)); err != nil {
+		master.Close()
+		t.Skip("no pseudo-terminal:", err)
+	}
+	slave, err := os.OpenFile("/dev/pts/"+strconv.Itoa(int(ptyN)), os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		master.Close()
+		t.Skip("no pseudo-terminal:", err)
+	}
+	return master, slave
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent use
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// waitFor waits until buf contains str
+func waitFor(t *testing.T, buf *syncBuffer, str string) {
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(buf.String(), str) {
+		if time.Now().After(deadline) {
+			t.Fatalf("timeout waiting for %q, output: %q", str, buf.String())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestPagerPTY(t *testing.T) {
+	is := is.New(t)
+	master, slave := openPTY(t)
+	defer master.Close()
+	ws := winsize{Row: 6, Col: 30}
+	is.NotErr(ioctl(fileFd(slave), syscall.TIOCSWINSZ, unsafe.Pointer(&ws)))
+	width, height, err := terminalSize(fileFd(slave))
+	is.NotErr(err)
+	is.Equal([]int{width, height}, []int{30, 6})
+
+	output := &syncBuffer{}
+	go func() {
+		buf := make([]byte, 4096)
+		for {
+			n, err := master.Read(buf)
+			output.Write(buf[:n])
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	p := newTestPager(is, 20)
+	done := make(chan error)
+	go func() {
+		done <- p.Run(slave, slave)
+	}()
+	waitFor(t, output, "rows 1-4 of 20")
+	is.True(strings.HasPrefix(output.String(), "\x1b[?1049h\x1b[?25l\x1b[H"))
+
+	_, err = master.Write([]byte("G"))
+	is.NotErr(err)
+	waitFor(t, output, "rows 17-20 of 20")
+	_, err = master.Write([]byte("/golf\r"))
+	is.NotErr(err)
+	waitFor(t, output, "rows 7-10 of 20")
+	// resize redraws without a keypress
+	ws.Row = 8
+	is.NotErr(ioctl(fileFd(slave), syscall.TIOCSWINSZ, unsafe.Pointer(&ws)))
+	is.NotErr(syscall.Kill(os.Getpid(), syscall.SIGWINCH))
+	waitFor(t, output, "rows 7-12 of 20")
+	// raw mode: Ctrl+C is read as a key, not a signal
+	_, err = master.Write([]byte{3})
+	is.NotErr(err)
+	select {
+	case err := <-done:
+		is.NotErr(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for pager to quit")
+	}
+	waitFor(t, output, "\x1b[?25h\x1b[?1049l")
+
+	// terminal mode is restored
+	termios := syscall.Termios{}
+	is.NotErr(ioctl(fileFd(slave), ioctlReadTermios, unsafe.Pointer(&termios)))
+	is.True(termios.Lflag&syscall.ICANON != 0)
+	is.True(termios.Lflag&syscall.ECHO != 0)
+	slave.Close()
+}
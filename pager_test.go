@@ -0,0 +1,169 @@
+package table
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+func newTestPager(is *is.Is, rowN int) *Pager {
+	tab := newTestTable("id", "name", "size", "kind")
+	tab.SetColor(false)
+	tab.ColumnByName["size"].Alignment = AlignmentRight
+	items := []any{}
+	names := []string{"alpha", "bravo", "charlie", "delta", "echo", "foxtrot", "golf", "hotel"}
+	for i := 0; i < rowN; i++ {
+		items = append(items, []any{i + 1, names[i%len(names)], (i * 37) % 100, "file"})
+	}
+	p, err := NewPager(tab, items)
+	is.NotErr(err)
+	return p
+}
+
+// viewLines returns lines of the pager view without escape sequences
+func viewLines(p *Pager) []string {
+	lines := strings.Split(StripANSI(p.View()), "\r\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return lines
+}
+
+func TestParsePagerKeys(t *testing.T) {
+	is := is.New(t)
+	keys := parsePagerKeys("j\x1b[A\x1bOB\x1b[6~\x1b[1;5C\x1bx\r\x7f\x03é\x1b")
+	kinds := []pagerKeyKind{}
+	for _, key := range keys {
+		kinds = append(kinds, key.kind)
+	}
+	is.Equal(kinds, []pagerKeyKind{
+		keyRune, keyUp, keyDown, keyPageDown, keyUnknown, keyEscape, keyRune,
+		keyEnter, keyBackspace, keyInterrupt, keyRune, keyEscape,
+	})
+	is.Equal(keys[0].r, 'j')
+	is.Equal(keys[6].r, 'x')
+	is.Equal(keys[10].r, 'é')
+}
+
+func TestPagerScroll(t *testing.T) {
+	is := is.New(t)
+	p := newTestPager(is, 20)
+	p.SetSize(30, 5)
+	is.Equal(viewLines(p), []string{
+		"id    name   size  kind",
+		"1   alpha       0  file",
+		"2   bravo      37  file",
+		"3   charlie    74  file",
+		"rows 1-3 of 20  column: id",
+	})
+	is.False(p.Update([]byte("jj")))
+	is.Equal(viewLines(p)[1], "3   charlie    74  file")
+	p.Update([]byte("G"))
+	is.Equal(viewLines(p)[1:], []string{
+		"18  bravo      29  file",
+		"19  charlie    66  file",
+		"20  delta       3  file",
+		"rows 18-20 of 20  column: id",
+	})
+	p.Update([]byte("\x1b[5~"))
+	is.Equal(viewLines(p)[1], "15  golf       18  file")
+	p.Update([]byte("gk"))
+	is.Equal(viewLines(p)[1], "1   alpha       0  file")
+	is.True(p.Update([]byte("q")))
+	is.True(p.Update([]byte{3}))
+}
+
+func TestPagerKeyColumns(t *testing.T) {
+	is := is.New(t)
+	p := newTestPager(is, 3)
+	p.KeyColumns = 1
+	p.SetSize(18, 6)
+	is.Equal(viewLines(p), []string{
+		"id │   name   size",
+		"1  │ alpha       0",
+		"2  │ bravo      37",
+		"3  │ charlie    74",
+		"~",
+		"rows 1-3 of 3  col",
+	})
+	p.Update([]byte("l"))
+	is.Equal(viewLines(p)[:2], []string{
+		"id │ size  kind",
+		"1  │    0  file",
+	})
+	p.Update([]byte("llll"))
+	is.Equal(viewLines(p)[1], "1  │ file")
+	p.Update([]byte("\x1b[D\x1b[D\x1b[D"))
+	is.Equal(viewLines(p)[1], "1  │ alpha       0")
+}
+
+func TestPagerSearch(t *testing.T) {
+	is := is.New(t)
+	p := newTestPager(is, 20)
+	p.SetSize(50, 5)
+	p.Update([]byte("/HOT"))
+	is.Equal(viewLines(p)[4], "/HOT")
+	is.Equal(viewLines(p)[1], "8   hotel      59  file")
+	is.True(strings.Contains(p.View(), "\x1b[7mhot\x1b[27mel"))
+	p.Update([]byte("\r"))
+	is.Equal(viewLines(p)[4], "rows 8-10 of 20  column: id  /HOT")
+	p.Update([]byte("n"))
+	is.Equal(viewLines(p)[1], "16  hotel      55  file")
+	p.Update([]byte("n"))
+	is.Equal(viewLines(p)[1], "8   hotel      59  file")
+	p.Update([]byte("N"))
+	is.Equal(viewLines(p)[1], "16  hotel      55  file")
+	p.Update([]byte("/\x1b"))
+	is.Equal(p.search, "")
+	p.Update([]byte("/zzz\r"))
+	is.Equal(viewLines(p)[4], "rows 16-18 of 20  column: id  /zzz  not found: zzz")
+	p.Update([]byte("/x\x7f\x7f\x7fa"))
+	is.Equal(p.search, "a")
+}
+
+func TestPagerHighlightStyled(t *testing.T) {
+	is := is.New(t)
+	p := newTestPager(is, 1)
+	p.search = "Lp"
+	is.Equal(
+		p.highlight("\x1b[31mhel\x1b[1mp\x1b[0m help"),
+		"\x1b[31mhe\x1b[7ml\x1b[1m\x1b[7mp\x1b[27m\x1b[0m he\x1b[7mlp\x1b[27m",
+	)
+	is.Equal(p.highlight("\x1b[32mnone\x1b[0m"), "\x1b[32mnone\x1b[0m")
+}
+
+func TestPagerSortAndColumnJump(t *testing.T) {
+	is := is.New(t)
+	p := newTestPager(is, 5)
+	p.KeyColumns = 1
+	p.SetSize(50, 7)
+	p.Update([]byte("cSI\r"))
+	is.Equal(viewLines(p)[0], "id │ size  kind")
+	p.Update([]byte("s"))
+	is.Equal(viewLines(p)[1:], []string{
+		"1  │    0  file",
+		"4  │   11  file",
+		"2  │   37  file",
+		"5  │   48  file",
+		"3  │   74  file",
+		"rows 1-5 of 5  column: size  sort: size ↑",
+	})
+	p.Update([]byte("s"))
+	is.Equal(viewLines(p)[1], "3  │   74  file")
+	is.Equal(viewLines(p)[6], "rows 1-5 of 5  column: size  sort: size ↓")
+	p.Update([]byte("s"))
+	is.Equal(viewLines(p)[1:3], []string{
+		"1  │    0  file",
+		"2  │   37  file",
+	})
+	p.Update([]byte("cnope\r"))
+	is.Equal(viewLines(p)[6], "rows 1-5 of 5  column: size  no column: nope")
+	p.Update([]byte("ck\r"))
+	is.Equal(viewLines(p)[0], "id │ kind")
+	// key columns are always shown
+	p.Update([]byte("ci\r"))
+	is.Equal(viewLines(p)[0], "id │ kind")
+	p.Update([]byte("cn\r"))
+	is.Equal(viewLines(p)[0], "id │   name   size  kind")
+}
@@ -0,0 +1,31 @@
+package table
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WritePlain writes the header and then one aligned item per line,
+// columns are separated by sep and rows are striped if Theme is set
+func (t *Table) WritePlain(out io.Writer, items FormattedItemList, sep string) error {
+	styledSep := t.styledSeparator(sep)
+	_, err := fmt.Fprintln(out, strings.Join(t.headerCells(), styledSep))
+	if err != nil {
+		return err
+	}
+	itemN := items.Len()
+	for itemIdx := 0; itemIdx < itemN; itemIdx++ {
+		item := items.Get(itemIdx)
+		cells := make([]string, len(item))
+		for colI, col := range t.Columns {
+			cells[colI] = t.alignCell(colI, item[colI], t.Width(col.Name))
+		}
+		line := t.styleRow(strings.Join(cells, styledSep), itemIdx)
+		_, err := fmt.Fprintln(out, line)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
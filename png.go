@@ -0,0 +1,257 @@
+package table
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+)
+
+// bitmap font cell size, glyphs are 5x7 pixels at offset (0, 1)
+const (
+	fontCellW = 6
+	fontCellH = 10
+)
+
+// font5x7 is a 5x7 bitmap font for ASCII 0x20-0x7e, each glyph is 5
+// columns with bit 0 at the top
+var font5x7 = [95][5]byte{
+	{0x00, 0x00, 0x00, 0x00, 0x00}, // ' '
+	{0x00, 0x00, 0x5f, 0x00, 0x00}, // !
+	{0x00, 0x07, 0x00, 0x07, 0x00}, // "
+	{0x14, 0x7f, 0x14, 0x7f, 0x14}, // #
+	{0x24, 0x2a, 0x7f, 0x2a, 0x12}, // $
+	{0x23, 0x13, 0x08, 0x64, 0x62}, // %
+	{0x36, 0x49, 0x55, 0x22, 0x50}, // &
+	{0x00, 0x05, 0x03, 0x00, 0x00}, // '
+	{0x00, 0x1c, 0x22, 0x41, 0x00}, // (
+	{0x00, 0x41, 0x22, 0x1c, 0x00}, // )
+	{0x08, 0x2a, 0x1c, 0x2a, 0x08}, // *
+	{0x08, 0x08, 0x3e, 0x08, 0x08}, // +
+	{0x00, 0x50, 0x30, 0x00, 0x00}, // ,
+	{0x08, 0x08, 0x08, 0x08, 0x08}, // -
+	{0x00, 0x60, 0x60, 0x00, 0x00}, // .
+	{0x20, 0x10, 0x08, 0x04, 0x02}, // /
+	{0x3e, 0x51, 0x49, 0x45, 0x3e}, // 0
+	{0x00, 0x42, 0x7f, 0x40, 0x00}, // 1
+	{0x42, 0x61, 0x51, 0x49, 0x46}, // 2
+	{0x21, 0x41, 0x45, 0x4b, 0x31}, // 3
+	{0x18, 0x14, 0x12, 0x7f, 0x10}, // 4
+	{0x27, 0x45, 0x45, 0x45, 0x39}, // 5
+	{0x3c, 0x4a, 0x49, 0x49, 0x30}, // 6
+	{0x01, 0x71, 0x09, 0x05, 0x03}, // 7
+	{0x36, 0x49, 0x49, 0x49, 0x36}, // 8
+	{0x06, 0x49, 0x49, 0x29, 0x1e}, // 9
+	{0x00, 0x36, 0x36, 0x00, 0x00}, // :
+	{0x00, 0x56, 0x36, 0x00, 0x00}, // ;
+	{0x08, 0x14, 0x22, 0x41, 0x00}, // <
+	{0x14, 0x14, 0x14, 0x14, 0x14}, // =
+	{0x00, 0x41, 0x22, 0x14, 0x08}, // >
+	{0x02, 0x01, 0x51, 0x09, 0x06}, // ?
+	{0x32, 0x49, 0x79, 0x41, 0x3e}, // @
+	{0x7e, 0x11, 0x11, 0x11, 0x7e}, // A
+	{0x7f, 0x49, 0x49, 0x49, 0x36}, // B
+	{0x3e, 0x41, 0x41, 0x41, 0x22}, // C
+	{0x7f, 0x41, 0x41, 0x22, 0x1c}, // D
+	{0x7f, 0x49, 0x49, 0x49, 0x41}, // E
+	{0x7f, 0x09, 0x09, 0x09, 0x01}, // F
+	{0x3e, 0x41, 0x49, 0x49, 0x7a}, // G
+	{0x7f, 0x08, 0x08, 0x08, 0x7f}, // H
+	{0x00, 0x41, 0x7f, 0x41, 0x00}, // I
+	{0x20, 0x40, 0x41, 0x3f, 0x01}, // J
+	{0x7f, 0x08, 0x14, 0x22, 0x41}, // K
+	{0x7f, 0x40, 0x40, 0x40, 0x40}, // L
+	{0x7f, 0x02, 0x0c, 0x02, 0x7f}, // M
+	{0x7f, 0x04, 0x08, 0x10, 0x7f}, // N
+	{0x3e, 0x41, 0x41, 0x41, 0x3e}, // O
+	{0x7f, 0x09, 0x09, 0x09, 0x06}, // P
+	{0x3e, 0x41, 0x51, 0x21, 0x5e}, // Q
+	{0x7f, 0x09, 0x19, 0x29, 0x46}, // R
+	{0x46, 0x49, 0x49, 0x49, 0x31}, // S
+	{0x01, 0x01, 0x7f, 0x01, 0x01}, // T
+	{0x3f, 0x40, 0x40, 0x40, 0x3f}, // U
+	{0x1f, 0x20, 0x40, 0x20, 0x1f}, // V
+	{0x3f, 0x40, 0x38, 0x40, 0x3f}, // W
+	{0x63, 0x14, 0x08, 0x14, 0x63}, // X
+	{0x07, 0x08, 0x70, 0x08, 0x07}, // Y
+	{0x61, 0x51, 0x49, 0x45, 0x43}, // Z
+	{0x00, 0x7f, 0x41, 0x41, 0x00}, // [
+	{0x02, 0x04, 0x08, 0x10, 0x20}, // \
+	{0x00, 0x41, 0x41, 0x7f, 0x00}, // ]
+	{0x04, 0x02, 0x01, 0x02, 0x04}, // ^
+	{0x40, 0x40, 0x40, 0x40, 0x40}, // _
+	{0x00, 0x01, 0x02, 0x04, 0x00}, // `
+	{0x20, 0x54, 0x54, 0x54, 0x78}, // a
+	{0x7f, 0x48, 0x44, 0x44, 0x38}, // b
+	{0x38, 0x44, 0x44, 0x44, 0x20}, // c
+	{0x38, 0x44, 0x44, 0x48, 0x7f}, // d
+	{0x38, 0x54, 0x54, 0x54, 0x18}, // e
+	{0x08, 0x7e, 0x09, 0x01, 0x02}, // f
+	{0x0c, 0x52, 0x52, 0x52, 0x3e}, // g
+	{0x7f, 0x08, 0x04, 0x04, 0x78}, // h
+	{0x00, 0x44, 0x7d, 0x40, 0x00}, // i
+	{0x20, 0x40, 0x44, 0x3d, 0x00}, // j
+	{0x7f, 0x10, 0x28, 0x44, 0x00}, // k
+	{0x00, 0x41, 0x7f, 0x40, 0x00}, // l
+	{0x7c, 0x04, 0x18, 0x04, 0x78}, // m
+	{0x7c, 0x08, 0x04, 0x04, 0x78}, // n
+	{0x38, 0x44, 0x44, 0x44, 0x38}, // o
+	{0x7c, 0x14, 0x14, 0x14, 0x08}, // p
+	{0x08, 0x14, 0x14, 0x18, 0x7c}, // q
+	{0x7c, 0x08, 0x04, 0x04, 0x08}, // r
+	{0x48, 0x54, 0x54, 0x54, 0x20}, // s
+	{0x04, 0x3f, 0x44, 0x40, 0x20}, // t
+	{0x3c, 0x40, 0x40, 0x20, 0x7c}, // u
+	{0x1c, 0x20, 0x40, 0x20, 0x1c}, // v
+	{0x3c, 0x40, 0x30, 0x40, 0x3c}, // w
+	{0x44, 0x28, 0x10, 0x28, 0x44}, // x
+	{0x0c, 0x50, 0x50, 0x50, 0x3c}, // y
+	{0x44, 0x64, 0x54, 0x4c, 0x44}, // z
+	{0x00, 0x08, 0x36, 0x41, 0x00}, // {
+	{0x00, 0x00, 0x7f, 0x00, 0x00}, // |
+	{0x00, 0x41, 0x36, 0x08, 0x00}, // }
+	{0x08, 0x04, 0x08, 0x10, 0x08}, // ~
+}
+
+// box drawing arms
+const (
+	armLeft = 1 << iota
+	armRight
+	armUp
+	armDown
+)
+
+var boxDrawingArms = map[rune]int{
+	'─': armLeft | armRight,
+	'│': armUp | armDown,
+	'┌': armRight | armDown,
+	'┐': armLeft | armDown,
+	'└': armRight | armUp,
+	'┘': armLeft | armUp,
+	'├': armUp | armDown | armRight,
+	'┤': armUp | armDown | armLeft,
+	'┬': armLeft | armRight | armDown,
+	'┴': armLeft | armRight | armUp,
+	'┼': armLeft | armRight | armUp | armDown,
+}
+
+// pixelCanvas draws pixels of the bitmap font, scaled
+type pixelCanvas struct {
+	img   *image.RGBA
+	scale int
+}
+
+func (c *pixelCanvas) set(x int, y int, clr color.Color) {
+	for dy := 0; dy < c.scale; dy++ {
+		for dx := 0; dx < c.scale; dx++ {
+			c.img.Set(x*c.scale+dx, y*c.scale+dy, clr)
+		}
+	}
+}
+
+func (c *pixelCanvas) fill(x int, y int, w int, h int, clr color.Color) {
+	for py := y; py < y+h; py++ {
+		for px := x; px < x+w; px++ {
+			c.set(px, py, clr)
+		}
+	}
+}
+
+// drawGlyph draws cluster at pixel position (x, y) (unscaled),
+// width is the number of cells it takes
+func (c *pixelCanvas) drawGlyph(x int, y int, cluster string, width int, bold bool, clr color.Color) {
+	r := []rune(cluster)[0]
+	if r >= 0x20 && r <= 0x7e {
+		glyph := font5x7[r-0x20]
+		for col, bits := range glyph {
+			for row := 0; row < 7; row++ {
+				if bits&(1<<row) == 0 {
+					continue
+				}
+				c.set(x+col, y+1+row, clr)
+				if bold {
+					c.set(x+col+1, y+1+row, clr)
+				}
+			}
+		}
+		return
+	}
+	if arms, ok := boxDrawingArms[r]; ok {
+		cx, cy := x+2, y+4
+		if arms&armLeft != 0 {
+			c.fill(x, cy, 3, 1, clr)
+		}
+		if arms&armRight != 0 {
+			c.fill(cx, cy, fontCellW-2, 1, clr)
+		}
+		if arms&armUp != 0 {
+			c.fill(cx, y, 1, 5, clr)
+		}
+		if arms&armDown != 0 {
+			c.fill(cx, cy, 1, fontCellH-4, clr)
+		}
+		return
+	}
+	if r == ' ' || r == '　' {
+		return
+	}
+	// no glyph: draw a box as wide as the character
+	w := width*fontCellW - 2
+	c.fill(x, y+1, w, 1, clr)
+	c.fill(x, y+7, w, 1, clr)
+	c.fill(x, y+1, 1, 7, clr)
+	c.fill(x+w-1, y+1, 1, 7, clr)
+}
+
+func rgbaColor(c Color) color.RGBA {
+	r, g, b := c.rgb()
+	return color.RGBA{R: r, G: g, B: b, A: 0xff}
+}
+
+// RenderPNG renders terminal output text as a PNG image using a bundled
+// bitmap font (ASCII and box drawing characters, other characters are
+// drawn as boxes), wide (CJK) characters take two cells
+func RenderPNG(out io.Writer, text string, opts *ImageOptions) error {
+	if opts == nil {
+		opts = &ImageOptions{}
+	}
+	scale := opts.Scale
+	if scale <= 0 {
+		scale = 2
+	}
+	defaultFg, defaultBg := opts.colors()
+	grid := parseScreen(text)
+	cols, rows := screenSize(grid)
+	// one cell margin on each side
+	img := image.NewRGBA(image.Rect(0, 0, (cols+2)*fontCellW*scale, (rows+1)*fontCellH*scale))
+	canvas := &pixelCanvas{img: img, scale: scale}
+	canvas.fill(0, 0, (cols+2)*fontCellW, (rows+1)*fontCellH, rgbaColor(defaultBg))
+	for rowI, row := range grid {
+		y := rowI*fontCellH + fontCellH/2
+		for colI, cell := range row {
+			if cell.cont {
+				continue
+			}
+			x := (colI + 1) * fontCellW
+			fg, bg := cell.style.colors(defaultFg, defaultBg)
+			if bg != defaultBg {
+				canvas.fill(x, y, cell.width*fontCellW, fontCellH, rgbaColor(bg))
+			}
+			canvas.drawGlyph(x, y, cell.text, cell.width, cell.style.Bold, rgbaColor(fg))
+			if cell.style.Underline {
+				canvas.fill(x, y+fontCellH-1, cell.width*fontCellW, 1, rgbaColor(fg))
+			}
+		}
+	}
+	return png.Encode(out, img)
+}
+
+// WritePNG renders the table as a PNG image, see RenderPNG.
+// If border is nil, the table is rendered without borders
+func (t *Table) WritePNG(out io.Writer, items FormattedItemList, border *Border, opts *ImageOptions) error {
+	text, err := t.renderTable(items, border)
+	if err != nil {
+		return err
+	}
+	return RenderPNG(out, text, opts)
+}
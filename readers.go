@@ -0,0 +1,498 @@
+package table
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrNoTable is returned by readers if no table is found in the input
+var ErrNoTable = errors.New("no table found")
+
+// readTextLines returns lines of r with escape sequences removed
+// and tabs expanded
+func readTextLines(r io.Reader) ([]string, error) {
+	lines := []string{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, expandTabs(StripANSI(strings.TrimRight(scanner.Text(), "\r"))))
+	}
+	return lines, scanner.Err()
+}
+
+var markdownDelimiterCell = regexp.MustCompile(`^:?-+:?$`)
+
+// splitMarkdownRow splits a pipe table row into cells, leading and
+// trailing pipes are optional and `\|` is a pipe inside a cell
+func splitMarkdownRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	cells := []string{}
+	var sb strings.Builder
+	escaped := false
+	for _, c := range line {
+		switch {
+		case escaped:
+			if c != '|' {
+				sb.WriteByte('\\')
+			}
+			sb.WriteRune(c)
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '|':
+			cells = append(cells, sb.String())
+			sb.Reset()
+		default:
+			sb.WriteRune(c)
+		}
+	}
+	if escaped {
+		sb.WriteByte('\\')
+	}
+	if sb.Len() > 0 || !strings.HasSuffix(line, "|") {
+		cells = append(cells, sb.String())
+	}
+	for i, cell := range cells {
+		cells[i] = strings.TrimSpace(cell)
+	}
+	return cells
+}
+
+var markdownUnescaper = regexp.MustCompile("\\\\([!-/:-@\\[-`{-~])|<br\\s*/?>")
+
+// markdownUnescape removes backslash escapes of punctuation and replaces
+// <br> with line breaks, reversing markdownEscaper
+func markdownUnescape(str string) string {
+	return markdownUnescaper.ReplaceAllStringFunc(str, func(match string) string {
+		if strings.HasPrefix(match, "<") {
+			return "\n"
+		}
+		return match[1:]
+	})
+}
+
+// markdownAlignments parses a delimiter row, ok is false if line
+// is not a delimiter row of n columns
+func markdownAlignments(line string, n int) ([]Alignment, bool) {
+	if !strings.Contains(line, "-") {
+		return nil, false
+	}
+	cells := splitMarkdownRow(line)
+	if len(cells) != n {
+		return nil, false
+	}
+	alignments := make([]Alignment, n)
+	for i, cell := range cells {
+		if !markdownDelimiterCell.MatchString(cell) {
+			return nil, false
+		}
+		left := strings.HasPrefix(cell, ":")
+		right := strings.HasSuffix(cell, ":")
+		switch {
+		case left && right:
+			alignments[i] = AlignmentCenter
+		case right:
+			alignments[i] = AlignmentRight
+		case left:
+			alignments[i] = AlignmentLeft
+		}
+	}
+	return alignments, true
+}
+
+// ParseMarkdown parses the first GitHub Flavored Markdown (pipe) table
+// in r. Column.Alignment is set from the delimiter row, and is nil for
+// columns without alignment. Backslash escapes are removed and <br> is
+// replaced with a line break, so the output of WriteMarkdown can be read
+// back. Returns ErrNoTable if there is no table
+func ParseMarkdown(r io.Reader) (*TextTable, error) {
+	lines, err := readTextLines(r)
+	if err != nil {
+		return nil, err
+	}
+	for lineI := 0; lineI+1 < len(lines); lineI++ {
+		if !strings.Contains(lines[lineI], "|") {
+			continue
+		}
+		titles := splitMarkdownRow(lines[lineI])
+		alignments, ok := markdownAlignments(lines[lineI+1], len(titles))
+		if !ok {
+			continue
+		}
+		for i, title := range titles {
+			titles[i] = markdownUnescape(title)
+		}
+		parsed := &TextTable{
+			Columns: newTextColumns(titles),
+			Rows:    [][]string{},
+		}
+		for i, col := range parsed.Columns {
+			col.Alignment = alignments[i]
+		}
+		for _, line := range lines[lineI+2:] {
+			if strings.TrimSpace(line) == "" {
+				break
+			}
+			cells := splitMarkdownRow(line)
+			row := make([]string, len(titles))
+			for i := range row {
+				if i < len(cells) {
+					row[i] = markdownUnescape(cells[i])
+				}
+			}
+			parsed.Rows = append(parsed.Rows, row)
+		}
+		return parsed, nil
+	}
+	return nil, ErrNoTable
+}
+
+const (
+	boxHorizontals = "-=─━═┄┅┈┉"
+	boxVerticals   = "|│┃║┆┇┊┋"
+	boxJunctions   = "+┌┐└┘├┤┬┴┼┏┓┗┛┣┫┳┻╋╔╗╚╝╠╣╦╩╬╒╕╘╛╞╡╤╧╪╓╖╙╜╟╢╥╨╫┝┥┯┷┿"
+)
+
+// isBoxRule returns true for border lines like "+---+", "├───┼───┤"
+// or "----+----"
+func isBoxRule(line string) bool {
+	line = strings.TrimSpace(line)
+	if !strings.ContainsAny(line, boxHorizontals) {
+		return false
+	}
+	for _, c := range line {
+		if c != ' ' && !strings.ContainsRune(boxHorizontals+boxVerticals+boxJunctions, c) {
+			return false
+		}
+	}
+	return true
+}
+
+// boxFooter matches the row count footer of psql
+var boxFooter = regexp.MustCompile(`^\(\d+ rows?\)$`)
+
+// bottomBoxRule returns index of the bottom border closing the body of
+// a framed table, which is the last border line if no framed rows follow
+// it, or -1 if the table is not closed (like truncated output)
+func bottomBoxRule(lines []string) int {
+	for i := len(lines) - 1; i >= 0; i-- {
+		if isBoxRule(lines[i]) {
+			return i
+		}
+		line := strings.TrimSpace(lines[i])
+		if line != "" && strings.ContainsRune(boxVerticals, []rune(line)[0]) {
+			return -1
+		}
+	}
+	return -1
+}
+
+// splitBoxLine splits a line by vertical border characters, used
+// when there is no border line to find column positions
+func splitBoxLine(line string) []string {
+	line = strings.TrimSpace(line)
+	fields := strings.FieldsFunc(line, func(c rune) bool {
+		return strings.ContainsRune(boxVerticals, c)
+	})
+	cells := make([]string, len(fields))
+	for i, field := range fields {
+		cells[i] = strings.TrimSpace(field)
+	}
+	return cells
+}
+
+// ParseBoxed parses a table drawn with ASCII or Unicode box characters,
+// like output of mysql, psql and WriteBordered. The first line between
+// borders is the header. Columns are split at junctions of the first
+// border line (so cells may contain vertical bars) and their Alignment
+// is inferred from positions of cells. Returns ErrNoTable if r has no lines
+func ParseBoxed(r io.Reader) (*TextTable, error) {
+	lines, err := readTextLines(r)
+	if err != nil {
+		return nil, err
+	}
+	rule := ""
+	content := [][]screenCell{}
+	texts := []string{}
+	framed := false
+	bottom := bottomBoxRule(lines)
+	for lineI, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "", boxFooter.MatchString(trimmed):
+			continue
+		case isBoxRule(line):
+			if rule == "" {
+				rule = line
+				framed = len(content) == 0
+			}
+			continue
+		}
+		if framed && bottom >= 0 && lineI > bottom {
+			// after the bottom border, like "2 rows in set" of mysql
+			break
+		}
+		content = append(content, parseScreen(line)[0])
+		texts = append(texts, line)
+	}
+	if len(content) == 0 {
+		return nil, ErrNoTable
+	}
+	if rule == "" {
+		return parseBoxedFields(texts), nil
+	}
+	ruleCells := parseScreen(rule)[0]
+	junctions := []int{}
+	for col, cell := range ruleCells {
+		if cell.text != "" && strings.ContainsAny(cell.text, boxJunctions+boxVerticals) {
+			junctions = append(junctions, col)
+		}
+	}
+	// regions of display columns between junctions
+	regions := []textSpan{}
+	start := 0
+	for _, col := range junctions {
+		if col > start {
+			regions = append(regions, textSpan{start: start, end: col})
+		}
+		start = col + 1
+	}
+	if start < len(ruleCells) {
+		regions = append(regions, textSpan{start: start, end: -1})
+	}
+	header, rows := content[0], content[1:]
+	titles := make([]string, len(regions))
+	for i, region := range regions {
+		titles[i] = cellText(header, region.start, region.end)
+	}
+	parsed := &TextTable{
+		Columns: newTextColumns(titles),
+		Rows:    make([][]string, len(rows)),
+	}
+	for rowI, row := range rows {
+		cells := make([]string, len(regions))
+		for i, region := range regions {
+			cells[i] = cellText(row, region.start, region.end)
+		}
+		parsed.Rows[rowI] = cells
+	}
+	for i, col := range parsed.Columns {
+		region := regions[i]
+		end := region.end
+		if end < 0 {
+			end = len(header)
+		}
+		title := textSpan{start: region.start, end: region.start}
+		if end > region.start {
+			if spans := nonBlankSpans(header[region.start:end]); len(spans) > 0 {
+				title = textSpan{start: region.start + spans[0].start, end: region.start + spans[len(spans)-1].end}
+			}
+		}
+		col.Alignment = inferAlignment(header, rows, title, region.start, region.end)
+	}
+	return parsed, nil
+}
+
+// parseBoxedFields parses lines of a box-drawn table without border
+// lines, by splitting at vertical border characters
+func parseBoxedFields(lines []string) *TextTable {
+	titles := splitBoxLine(lines[0])
+	parsed := &TextTable{
+		Columns: newTextColumns(titles),
+		Rows:    make([][]string, 0, len(lines)-1),
+	}
+	for _, line := range lines[1:] {
+		fields := splitBoxLine(line)
+		row := make([]string, len(titles))
+		copy(row, fields)
+		parsed.Rows = append(parsed.Rows, row)
+	}
+	return parsed
+}
+
+// CSVHeader tells ParseCSV if the first record is the header
+type CSVHeader int
+
+const (
+	// CSVHeaderAuto detects the header, see DetectCSVHeader
+	CSVHeaderAuto CSVHeader = iota
+	CSVHeaderPresent
+	CSVHeaderAbsent
+)
+
+// CSVOptions are options of ParseCSV
+type CSVOptions struct {
+	// Comma is the field delimiter, if zero it is detected from the
+	// first line among comma, semicolon, tab and vertical bar
+	Comma  rune
+	Header CSVHeader
+}
+
+// detectCSVComma returns the most frequent delimiter (outside of quotes)
+// in the first line of data, or comma
+func detectCSVComma(data []byte) rune {
+	counts := map[rune]int{}
+	quoted := false
+	for _, c := range string(data) {
+		if c == '"' {
+			quoted = !quoted
+			continue
+		}
+		if c == '\n' && !quoted {
+			break
+		}
+		if !quoted && strings.ContainsRune(",;\t|", c) {
+			counts[c]++
+		}
+	}
+	comma := ','
+	for _, c := range []rune{';', '\t', '|'} {
+		if counts[c] > counts[comma] {
+			comma = c
+		}
+	}
+	return comma
+}
+
+// DetectCSVHeader returns true if the first record looks like a header:
+// its fields are non-empty and distinct, and differ from other records in
+// type (text above numbers) or in length where other records have the
+// same length in that column
+func DetectCSVHeader(records [][]string) bool {
+	if len(records) == 0 {
+		return false
+	}
+	first := records[0]
+	seen := map[string]bool{}
+	for _, field := range first {
+		if field == "" || seen[field] {
+			return false
+		}
+		seen[field] = true
+	}
+	rest := records[1:]
+	if len(rest) == 0 {
+		for _, field := range first {
+			if isNumber(field) {
+				return false
+			}
+		}
+		return true
+	}
+	votes := 0
+	for colI, field := range first {
+		numeric, length := true, -1
+		for _, record := range rest {
+			if colI >= len(record) || record[colI] == "" {
+				continue
+			}
+			if !isNumber(record[colI]) {
+				numeric = false
+			}
+			n := len([]rune(record[colI]))
+			switch length {
+			case -1:
+				length = n
+			case n:
+			default:
+				length = -2
+			}
+		}
+		switch {
+		case length == -1:
+			// no values
+		case numeric:
+			if isNumber(field) {
+				votes--
+			} else {
+				votes++
+			}
+		case length >= 0:
+			if len([]rune(field)) == length {
+				votes--
+			} else {
+				votes++
+			}
+		}
+	}
+	return votes > 0
+}
+
+// ParseCSV parses CSV data, with a header detected (or set) by opts.
+// Without a header, columns are named column1, column2 and so on.
+// Columns with only numbers are aligned to the right
+func ParseCSV(r io.Reader, opts *CSVOptions) (*TextTable, error) {
+	if opts == nil {
+		opts = &CSVOptions{}
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = opts.Comma
+	if reader.Comma == 0 {
+		reader.Comma = detectCSVComma(data)
+	}
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return &TextTable{Columns: []*Column{}, Rows: [][]string{}}, nil
+	}
+	hasHeader := opts.Header == CSVHeaderPresent
+	if opts.Header == CSVHeaderAuto {
+		hasHeader = DetectCSVHeader(records)
+	}
+	colN := 0
+	for _, record := range records {
+		if len(record) > colN {
+			colN = len(record)
+		}
+	}
+	titles := make([]string, colN)
+	if hasHeader {
+		copy(titles, records[0])
+		records = records[1:]
+	}
+	for i, title := range titles {
+		if title == "" {
+			titles[i] = "column" + strconv.Itoa(i+1)
+		}
+	}
+	parsed := &TextTable{
+		Columns: newTextColumns(titles),
+		Rows:    make([][]string, len(records)),
+	}
+	for i, record := range records {
+		row := make([]string, colN)
+		copy(row, record)
+		parsed.Rows[i] = row
+	}
+	for colI, col := range parsed.Columns {
+		numeric, found := true, false
+		for _, row := range parsed.Rows {
+			if row[colI] == "" {
+				continue
+			}
+			found = true
+			if !isNumber(row[colI]) {
+				numeric = false
+				break
+			}
+		}
+		if numeric && found {
+			col.Alignment = AlignmentRight
+		}
+	}
+	return parsed, nil
+}
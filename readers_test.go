@@ -0,0 +1,174 @@
+package table
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestParseMarkdown(t *testing.T) {
+	is := is.New(t)
+	parsed, err := ParseMarkdown(strings.NewReader(`Some text
+
+| a | b \| c | d |
+|:--|--:|:-:|
+| x\\y | 1<br>2 | |
+| z |
+
+after the table
+`))
+	is.NotErr(err)
+	is.Equal(columnTitles(parsed.Columns), []string{"a", "b | c", "d"})
+	is.Equal(alignmentNames(parsed.Columns), []string{"left", "right", "center"})
+	is.Equal(parsed.Rows, [][]string{
+		{`x\y`, "1\n2", ""},
+		{"z", "", ""},
+	})
+
+	_, err = ParseMarkdown(strings.NewReader("a | b\nc | d\n"))
+	is.Equal(err, ErrNoTable)
+}
+
+func TestParseMarkdownRoundTrip(t *testing.T) {
+	is := is.New(t)
+	tab := newTestTable("name", "note")
+	tab.ColumnByName["note"].Alignment = AlignmentRight
+	buf := &bytes.Buffer{}
+	is.NotErr(tab.WriteMarkdown(buf, []any{
+		[]any{"a|b", `back\slash`},
+		[]any{"two\nlines", ""},
+	}))
+	parsed, err := ParseMarkdown(buf)
+	is.NotErr(err)
+	is.Equal(columnTitles(parsed.Columns), []string{"name", "note"})
+	is.Equal(alignmentNames(parsed.Columns), []string{"", "right"})
+	is.Equal(parsed.Rows, [][]string{
+		{"a|b", `back\slash`},
+		{"two\nlines", ""},
+	})
+}
+
+func TestParseBoxed(t *testing.T) {
+	is := is.New(t)
+	// mysql
+	parsed, err := ParseBoxed(strings.NewReader(`+----+-------+--------+
+| id | name  | amount |
++----+-------+--------+
+|  1 | a|b   |   1.50 |
+| 22 | Bob   |  10.00 |
++----+-------+--------+
+2 rows in set (0.00 sec)
+`))
+	is.NotErr(err)
+	is.Equal(columnTitles(parsed.Columns), []string{"id", "name", "amount"})
+	is.Equal(alignmentNames(parsed.Columns), []string{"right", "left", "right"})
+	is.Equal(parsed.Rows, [][]string{{"1", "a|b", "1.50"}, {"22", "Bob", "10.00"}})
+
+	// psql
+	parsed, err = ParseBoxed(strings.NewReader(` id | name  | amount
+----+-------+--------
+  1 | alice |   1.50
+ 22 | bob   |  10.00
+(2 rows)
+`))
+	is.NotErr(err)
+	is.Equal(columnTitles(parsed.Columns), []string{"id", "name", "amount"})
+	is.Equal(alignmentNames(parsed.Columns), []string{"right", "left", "right"})
+	is.Equal(parsed.Rows, [][]string{{"1", "alice", "1.50"}, {"22", "bob", "10.00"}})
+
+	// no border lines
+	parsed, err = ParseBoxed(strings.NewReader("│ a │ b │\n│ 1 │ 2 │\n"))
+	is.NotErr(err)
+	is.Equal(columnTitles(parsed.Columns), []string{"a", "b"})
+	is.Equal(parsed.Rows, [][]string{{"1", "2"}})
+
+	// truncated, without the bottom border
+	parsed, err = ParseBoxed(strings.NewReader(`┌────┬──────┐
+│ id │ name │
+├────┼──────┤
+│  1 │ a    │
+│  2 │ b    │
+`))
+	is.NotErr(err)
+	is.Equal(columnTitles(parsed.Columns), []string{"id", "name"})
+	is.Equal(parsed.Rows, [][]string{{"1", "a"}, {"2", "b"}})
+
+	_, err = ParseBoxed(strings.NewReader("+---+\n"))
+	is.Equal(err, ErrNoTable)
+}
+
+func TestParseBoxedRoundTrip(t *testing.T) {
+	is := is.New(t)
+	tab := newTestTable("name", "size")
+	tab.ColumnByName["size"].Alignment = AlignmentRight
+	formatted := FormattedItems{}
+	for _, item := range [][]any{{"README.md", 1523}, {"さの.png", 87}} {
+		row, err := tab.FormatItem(item)
+		is.NotErr(err)
+		formatted = append(formatted, row)
+	}
+	for _, border := range []*Border{BorderLight, BorderASCII} {
+		buf := &bytes.Buffer{}
+		is.NotErr(tab.WriteBordered(buf, formatted, border))
+		parsed, err := ParseBoxed(buf)
+		is.NotErr(err)
+		is.Equal(columnTitles(parsed.Columns), []string{"name", "size"})
+		is.Equal(alignmentNames(parsed.Columns), []string{"left", "right"})
+		is.Equal(parsed.Rows, [][]string{{"README.md", "1523"}, {"さの.png", "87"}})
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	is := is.New(t)
+	parsed, err := ParseCSV(strings.NewReader("name;size\na;1\n\"b;c\";22\n"), nil)
+	is.NotErr(err)
+	is.Equal(columnTitles(parsed.Columns), []string{"name", "size"})
+	is.Equal(alignmentNames(parsed.Columns), []string{"left", "right"})
+	is.Equal(parsed.Rows, [][]string{{"a", "1"}, {"b;c", "22"}})
+
+	parsed, err = ParseCSV(strings.NewReader("a,1\nb,22,x\n"), nil)
+	is.NotErr(err)
+	is.Equal(columnTitles(parsed.Columns), []string{"column1", "column2", "column3"})
+	is.Equal(parsed.Rows, [][]string{{"a", "1", ""}, {"b", "22", "x"}})
+
+	parsed, err = ParseCSV(strings.NewReader("a,1\nb,22\n"), &CSVOptions{Header: CSVHeaderPresent})
+	is.NotErr(err)
+	is.Equal(columnTitles(parsed.Columns), []string{"a", "1"})
+	is.Equal(parsed.Rows, [][]string{{"b", "22"}})
+
+	for _, header := range []CSVHeader{CSVHeaderAuto, CSVHeaderPresent, CSVHeaderAbsent} {
+		parsed, err = ParseCSV(strings.NewReader(""), &CSVOptions{Header: header})
+		is.NotErr(err)
+		is.Equal(len(parsed.Columns), 0)
+		is.Equal(len(parsed.Rows), 0)
+	}
+}
+
+func TestDetectCSVHeader(t *testing.T) {
+	is := is.New(t)
+	is.True(DetectCSVHeader([][]string{{"id", "code"}, {"1", "AB"}, {"2", "CD"}}))
+	is.False(DetectCSVHeader([][]string{{"1", "AB"}, {"2", "CD"}}))
+	is.False(DetectCSVHeader([][]string{{"id", "id"}, {"1", "2"}}))
+	is.False(DetectCSVHeader([][]string{{"id", ""}, {"1", "2"}}))
+	is.True(DetectCSVHeader([][]string{{"name", "city"}}))
+	is.False(DetectCSVHeader([][]string{{"name", "1"}}))
+	is.False(DetectCSVHeader(nil))
+}
+
+func TestTextTableConvert(t *testing.T) {
+	is := is.New(t)
+	parsed, err := ParseCSV(strings.NewReader("name,size\nREADME.md,1523\nsrc,4096\n"), nil)
+	is.NotErr(err)
+	tab := parsed.Table()
+	is.Equal(tab.Width("name"), uint16(9))
+	is.Equal(tab.Width("size"), uint16(4))
+	buf := &bytes.Buffer{}
+	is.NotErr(tab.WritePlain(buf, parsed, "  "))
+	is.Equal(buf.String(), "   name    size\nREADME.md  1523\nsrc        4096\n")
+
+	buf.Reset()
+	is.NotErr(tab.WriteMarkdown(buf, parsed.Items()))
+	is.Equal(buf.String(), "| name      | size |\n| :-------- | ---: |\n| README.md | 1523 |\n| src       | 4096 |\n")
+}
@@ -0,0 +1,77 @@
+package table
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ilius/is/v2"
+)
+
+var (
+	recordsTestColumns = []string{"name", "size", "ok", "mtime", "note", "my key"}
+	recordsTestMtime   = time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC)
+	recordsTestRows    = [][]any{
+		{"a.txt", 12, true, recordsTestMtime, "line 1\nline 2", 1.0},
+		{"yes", -1, false, recordsTestMtime, nil, math.Inf(1)},
+		{"a: b", uint8(3), false, recordsTestMtime, "  x\n", "2023"},
+	}
+)
+
+func TestWriteYAML(t *testing.T) {
+	is := is.New(t)
+	tab, items := newTestTableItems(recordsTestColumns, recordsTestRows...)
+	out := &strings.Builder{}
+	is.NotErr(tab.WriteYAML(out, items))
+	is.Equal(out.String(), `- name: a.txt
+  size: 12
+  ok: true
+  mtime: 2023-01-02T12:00:00Z
+  note: |-
+    line 1
+    line 2
+  my key: 1.0
+- name: "yes"
+  size: -1
+  ok: false
+  mtime: 2023-01-02T12:00:00Z
+  note: null
+  my key: .inf
+- name: "a: b"
+  size: 3
+  ok: false
+  mtime: 2023-01-02T12:00:00Z
+  note: |2
+      x
+  my key: "2023"
+`)
+
+	out.Reset()
+	is.NotErr(tab.WriteYAML(out, nil))
+	is.Equal(out.String(), "[]\n")
+}
+
+func TestWriteTOML(t *testing.T) {
+	is := is.New(t)
+	tab, items := newTestTableItems(recordsTestColumns, recordsTestRows...)
+	out := &strings.Builder{}
+	is.NotErr(tab.WriteTOML(out, items[:2], "files"))
+	is.Equal(out.String(), `[[files]]
+name = "a.txt"
+size = 12
+ok = true
+mtime = 2023-01-02T12:00:00Z
+note = """
+line 1
+line 2"""
+"my key" = 1.0
+
+[[files]]
+name = "yes"
+size = -1
+ok = false
+mtime = 2023-01-02T12:00:00Z
+"my key" = inf
+`)
+}
@@ -0,0 +1,84 @@
+package table
+
+import (
+	"io"
+	"strings"
+)
+
+var rstEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"*", `\*`,
+	"`", "\\`",
+	"|", `\|`,
+)
+
+func (t *Table) alignRow(row []string, widths []uint16) []string {
+	cells := make([]string, len(row))
+	for i, cell := range row {
+		cells[i] = t.alignCell(i, cell, widths[i])
+	}
+	return cells
+}
+
+// WriteRSTGrid writes items as a reStructuredText grid table
+func (t *Table) WriteRSTGrid(out io.Writer, items []any) error {
+	header, rows, widths, err := t.textCells(items, rstEscaper.Replace)
+	if err != nil {
+		return err
+	}
+	rule := func(char string) string {
+		parts := make([]string, len(widths))
+		for i, w := range widths {
+			parts[i] = strings.Repeat(char, int(w)+2)
+		}
+		return "+" + strings.Join(parts, "+") + "+"
+	}
+	row := func(cells []string) string {
+		return "| " + strings.Join(cells, " | ") + " |"
+	}
+	lines := []string{rule("-")}
+	for i, cell := range header {
+		header[i] = AlignmentLeft(cell, widths[i])
+	}
+	lines = append(lines, row(header), rule("="))
+	for _, cells := range rows {
+		lines = append(lines, row(t.alignRow(cells, widths)), rule("-"))
+	}
+	_, err = io.WriteString(out, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+// WriteRSTSimple writes items as a reStructuredText simple table
+func (t *Table) WriteRSTSimple(out io.Writer, items []any) error {
+	header, rows, widths, err := t.textCells(items, rstEscaper.Replace)
+	if err != nil {
+		return err
+	}
+	for _, cells := range rows {
+		// empty first cell would make the row a continuation line
+		if len(cells) > 0 && cells[0] == "" {
+			cells[0] = `\ `
+			if widths[0] < 2 {
+				widths[0] = 2
+			}
+		}
+	}
+	parts := make([]string, len(widths))
+	for i, w := range widths {
+		parts[i] = strings.Repeat("=", int(w))
+	}
+	rule := strings.Join(parts, "  ")
+	row := func(cells []string) string {
+		return strings.TrimRight(strings.Join(cells, "  "), " ")
+	}
+	for i, cell := range header {
+		header[i] = AlignmentLeft(cell, widths[i])
+	}
+	lines := []string{rule, row(header), rule}
+	for _, cells := range rows {
+		lines = append(lines, row(t.alignRow(cells, widths)))
+	}
+	lines = append(lines, rule)
+	_, err = io.WriteString(out, strings.Join(lines, "\n")+"\n")
+	return err
+}
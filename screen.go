@@ -0,0 +1,92 @@
+package table
+
+import (
+	"strings"
+
+	"github.com/ilius/go-table/runewidth"
+	"github.com/ilius/go-table/runewidth/uniseg"
+)
+
+// screenCell is one cell of a monospace terminal grid, a wide character
+// takes a cell with width 2 followed by a continuation cell
+type screenCell struct {
+	text  string
+	style Style
+	width int
+	cont  bool
+}
+
+// parseScreen splits terminal output (with SGR sequences) into a grid of
+// cells per line, as the terminal would display it. Other control
+// sequences are dropped
+func parseScreen(text string) [][]screenCell {
+	text = strings.TrimSuffix(text, "\n")
+	lines := strings.Split(text, "\n")
+	grid := make([][]screenCell, len(lines))
+	style := Style{}
+	for lineI, line := range lines {
+		row := []screenCell{}
+		ForEachToken(line, func(tok Token) {
+			switch tok.Kind {
+			case TokenCSI:
+				if params, ok := sgrTokenParams(tok); ok {
+					style = applySGR(style, params)
+				}
+				return
+			case TokenText:
+			default:
+				return
+			}
+			rest := tok.Text
+			state := -1
+			for rest != "" {
+				var cluster string
+				cluster, rest, _, state = uniseg.StepString(rest, state)
+				width := runewidth.StringWidth(cluster)
+				if width == 0 {
+					if len(row) > 0 && cluster != "\r" && !strings.ContainsAny(cluster, "\x00\a\b") {
+						// combining mark
+						last := len(row) - 1
+						for last > 0 && row[last].cont {
+							last--
+						}
+						row[last].text += cluster
+					}
+					continue
+				}
+				row = append(row, screenCell{text: cluster, style: style, width: width})
+				for i := 1; i < width; i++ {
+					row = append(row, screenCell{style: style, cont: true})
+				}
+			}
+		})
+		grid[lineI] = row
+	}
+	return grid
+}
+
+// screenSize returns number of columns and rows of grid
+func screenSize(grid [][]screenCell) (int, int) {
+	cols := 0
+	for _, row := range grid {
+		if len(row) > cols {
+			cols = len(row)
+		}
+	}
+	return cols, len(grid)
+}
+
+// colors returns foreground and background colors of style, taking
+// Inverse into account, fg and bg are used for default colors
+func (s Style) colors(fg Color, bg Color) (Color, Color) {
+	if !s.Fg.IsDefault() {
+		fg = s.Fg
+	}
+	if !s.Bg.IsDefault() {
+		bg = s.Bg
+	}
+	if s.Inverse {
+		return bg, fg
+	}
+	return fg, bg
+}
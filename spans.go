@@ -0,0 +1,246 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HeaderGroup is a title over adjacent columns, in a header row above
+// column titles, like "Disk" over "used", "free" and "%"
+type HeaderGroup struct {
+	Title string
+	// Columns are names of adjacent columns, in order
+	Columns []string
+}
+
+// AddHeaderRow adds a row of grouped titles below previously added rows
+// and above column titles. Columns without a group have an empty cell
+// which is merged with the cell below it
+func (t *TableSpec) AddHeaderRow(groups ...*HeaderGroup) {
+	t.HeaderRows = append(t.HeaderRows, groups)
+}
+
+// headerGroupIndex returns index of the group of each column in each
+// header row, or -1 for columns without a group
+func (t *TableSpec) headerGroupIndex() ([][]int, error) {
+	levels := make([][]int, len(t.HeaderRows))
+	for rowI, groups := range t.HeaderRows {
+		level := make([]int, t.ColumnCount())
+		for colI := range level {
+			level[colI] = -1
+		}
+		for groupI, group := range groups {
+			if len(group.Columns) == 0 {
+				return nil, fmt.Errorf("header group %#v has no columns", group.Title)
+			}
+			start := t.columnIndex(group.Columns[0])
+			for i, colName := range group.Columns {
+				colI := t.columnIndex(colName)
+				if colI < 0 {
+					return nil, fmt.Errorf("invalid column %#v in header group %#v", colName, group.Title)
+				}
+				if colI != start+i {
+					return nil, fmt.Errorf("columns of header group %#v are not adjacent", group.Title)
+				}
+				if level[colI] >= 0 {
+					return nil, fmt.Errorf("column %#v is in more than one header group", colName)
+				}
+				level[colI] = groupI
+			}
+		}
+		levels[rowI] = level
+	}
+	return levels, nil
+}
+
+// CellSpan merges the cell of item index Row and column Column with
+// the cells of Rows-1 items below and Columns-1 columns to the right
+// (values less than 1 mean 1). Merged cells show the text of the first
+// cell, with the alignment of Column
+type CellSpan struct {
+	Row     int
+	Column  string
+	Rows    int
+	Columns int
+}
+
+// AddCellSpan adds a span of cells of items, used by WriteBordered,
+// WriteHTML and WriteMarkdown
+func (t *Table) AddCellSpan(span *CellSpan) {
+	t.spans = append(t.spans, span)
+}
+
+// ClearCellSpans removes spans added by AddCellSpan and SpanEqualRows
+func (t *Table) ClearCellSpans() {
+	t.spans = nil
+}
+
+// SpanEqualRows adds a span for each run of equal cells of column
+// colName in items, like a category shared by several rows
+func (t *Table) SpanEqualRows(items FormattedItemList, colName string) error {
+	colI := t.columnIndex(colName)
+	if colI < 0 {
+		return fmt.Errorf("invalid column %#v", colName)
+	}
+	itemN := items.Len()
+	for start := 0; start < itemN; {
+		text := items.Get(start)[colI]
+		end := start + 1
+		for end < itemN && items.Get(end)[colI] == text {
+			end++
+		}
+		if end-start > 1 {
+			t.AddCellSpan(&CellSpan{Row: start, Column: colName, Rows: end - start})
+		}
+		start = end
+	}
+	return nil
+}
+
+// cellPos is a row and column index
+type cellPos struct {
+	row int
+	col int
+}
+
+// spanGrid is the merged cell of each cell of rowN rows
+type spanGrid struct {
+	// owner is the position of the first cell of the merged cell
+	owner [][]cellPos
+	// size is the number of rows and columns of merged cells by owner
+	size map[cellPos]cellPos
+}
+
+// spanGrid resolves cell spans of rowN items, spans are clipped
+// to the table
+func (t *Table) spanGrid(rowN int) (*spanGrid, error) {
+	colN := t.ColumnCount()
+	grid := &spanGrid{
+		owner: make([][]cellPos, rowN),
+		size:  map[cellPos]cellPos{},
+	}
+	for row := range grid.owner {
+		grid.owner[row] = make([]cellPos, colN)
+		for col := range grid.owner[row] {
+			grid.owner[row][col] = cellPos{row: row, col: col}
+		}
+	}
+	for _, span := range t.spans {
+		col := t.columnIndex(span.Column)
+		if col < 0 {
+			return nil, fmt.Errorf("invalid column %#v in cell span", span.Column)
+		}
+		if span.Row < 0 || span.Row >= rowN {
+			continue
+		}
+		rows, cols := span.Rows, span.Columns
+		if rows < 1 {
+			rows = 1
+		}
+		if cols < 1 {
+			cols = 1
+		}
+		if span.Row+rows > rowN {
+			rows = rowN - span.Row
+		}
+		if col+cols > colN {
+			cols = colN - col
+		}
+		pos := cellPos{row: span.Row, col: col}
+		for row := pos.row; row < pos.row+rows; row++ {
+			for c := col; c < col+cols; c++ {
+				if grid.owner[row][c] != (cellPos{row: row, col: c}) || grid.size[cellPos{row: row, col: c}] != (cellPos{}) {
+					return nil, fmt.Errorf("overlapping cell spans at row %d, column %#v", row, t.Columns[c].Name)
+				}
+			}
+		}
+		for row := pos.row; row < pos.row+rows; row++ {
+			for c := col; c < col+cols; c++ {
+				grid.owner[row][c] = pos
+			}
+		}
+		grid.size[pos] = cellPos{row: rows, col: cols}
+	}
+	return grid, nil
+}
+
+// span returns the number of rows and columns of the merged cell
+// starting at pos
+func (grid *spanGrid) span(pos cellPos) (int, int) {
+	size, ok := grid.size[pos]
+	if !ok {
+		return 1, 1
+	}
+	return size.row, size.col
+}
+
+// boundaries returns whether there is a vertical border before each
+// column and after the last column of row
+func (grid *spanGrid) boundaries(row int) []bool {
+	owner := grid.owner[row]
+	bounds := make([]bool, len(owner)+1)
+	bounds[0], bounds[len(owner)] = true, true
+	for col := 1; col < len(owner); col++ {
+		bounds[col] = owner[col] != owner[col-1]
+	}
+	return bounds
+}
+
+// spreadWidth widens widths so that their sum with sepWidth between
+// them is at least width, extra width is spread evenly
+func spreadWidth(widths []uint16, width uint16, sepWidth int) {
+	total := sepWidth * (len(widths) - 1)
+	for _, w := range widths {
+		total += int(w)
+	}
+	extra := int(width) - total
+	if extra <= 0 {
+		return
+	}
+	for i := range widths {
+		add := extra / len(widths)
+		if i < extra%len(widths) {
+			add++
+		}
+		widths[i] += uint16(add)
+	}
+}
+
+// mergedWidth returns the width of widths merged with sepWidth
+// between them
+func mergedWidth(widths []uint16, sepWidth int) uint16 {
+	total := sepWidth * (len(widths) - 1)
+	for _, w := range widths {
+		total += int(w)
+	}
+	return uint16(total)
+}
+
+// junction returns the border string joining lines in given directions
+func (border *Border) junction(up bool, down bool, left bool, right bool) string {
+	switch {
+	case up && down && left && right:
+		return border.MidMid
+	case down && left && right:
+		return border.TopMid
+	case up && left && right:
+		return border.BottomMid
+	case up && down && right:
+		return border.MidLeft
+	case up && down && left:
+		return border.MidRight
+	case down && right:
+		return border.TopLeft
+	case down && left:
+		return border.TopRight
+	case up && right:
+		return border.BottomLeft
+	case up && left:
+		return border.BottomRight
+	case up || down:
+		return border.Vertical
+	case left || right:
+		return border.Horizontal
+	}
+	return strings.Repeat(" ", int(visualWidth(border.Horizontal)))
+}
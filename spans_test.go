@@ -0,0 +1,132 @@
+package table
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+func newTestSpanTable() *Table {
+	tab := newTestTable("category", "name", "used", "free", "pct")
+	tab.SetColor(false)
+	tab.AddHeaderRow(&HeaderGroup{Title: "Storage", Columns: []string{"used", "free", "pct"}})
+	tab.AddHeaderRow(&HeaderGroup{Title: "Disk", Columns: []string{"used", "free"}})
+	return tab
+}
+
+func testSpanItems() []any {
+	return []any{
+		[]any{"ssd", "sda", "10G", "90G", "10%"},
+		[]any{"ssd", "sdb", "1G", "9G", "10%"},
+		[]any{"hdd", "sdc", "total unknown", "", "?"},
+	}
+}
+
+func TestWriteBorderedSpans(t *testing.T) {
+	is := is.New(t)
+	tab := newTestSpanTable()
+	tab.UpdateWidth(map[string]uint16{"category": 8, "name": 4})
+	formatted := FormattedItems{}
+	for _, item := range testSpanItems() {
+		row, err := tab.FormatItem(item)
+		is.NotErr(err)
+		formatted = append(formatted, row)
+	}
+	is.NotErr(tab.SpanEqualRows(formatted, "category"))
+	tab.AddCellSpan(&CellSpan{Row: 2, Column: "used", Columns: 2})
+	buf := &bytes.Buffer{}
+	is.NotErr(tab.WriteBordered(buf, formatted, nil))
+	is.Equal(buf.String(), ""+
+		"┌──────────┬──────┬─────────────────────┐\n"+
+		"│          │      │       Storage       │\n"+
+		"│          │      ├───────────────┬─────┤\n"+
+		"│          │      │      Disk     │     │\n"+
+		"│          │      ├────────┬──────┤     │\n"+
+		"│ category │ name │  used  │ free │ pct │\n"+
+		"├──────────┼──────┼────────┼──────┼─────┤\n"+
+		"│ ssd      │ sda  │ 10G    │ 90G  │ 10% │\n"+
+		"│          │ sdb  │ 1G     │ 9G   │ 10% │\n"+
+		"│ hdd      │ sdc  │ total unknown │ ?   │\n"+
+		"└──────────┴──────┴───────────────┴─────┘\n")
+}
+
+func TestWriteHTMLSpans(t *testing.T) {
+	is := is.New(t)
+	tab := newTestSpanTable()
+	tab.AddCellSpan(&CellSpan{Row: 0, Column: "category", Rows: 2})
+	tab.AddCellSpan(&CellSpan{Row: 2, Column: "used", Columns: 2})
+	buf := &bytes.Buffer{}
+	is.NotErr(tab.WriteHTML(buf, testSpanItems(), nil))
+	is.Equal(buf.String(), `<table>
+<thead>
+<tr>
+<th rowspan="3">category</th>
+<th rowspan="3">name</th>
+<th colspan="3">Storage</th>
+</tr>
+<tr>
+<th colspan="2">Disk</th>
+<th rowspan="2">pct</th>
+</tr>
+<tr>
+<th>used</th>
+<th>free</th>
+</tr>
+</thead>
+<tbody>
+<tr>
+<td rowspan="2">ssd</td>
+<td>sda</td>
+<td>10G</td>
+<td>90G</td>
+<td>10%</td>
+</tr>
+<tr>
+<td>sdb</td>
+<td>1G</td>
+<td>9G</td>
+<td>10%</td>
+</tr>
+<tr>
+<td>hdd</td>
+<td>sdc</td>
+<td colspan="2">total unknown</td>
+<td>?</td>
+</tr>
+</tbody>
+</table>
+`)
+}
+
+func TestWriteMarkdownSpans(t *testing.T) {
+	is := is.New(t)
+	tab := newTestSpanTable()
+	tab.AddCellSpan(&CellSpan{Row: 0, Column: "category", Rows: 2})
+	tab.AddCellSpan(&CellSpan{Row: 2, Column: "used", Columns: 2})
+	buf := &bytes.Buffer{}
+	is.NotErr(tab.WriteMarkdown(buf, testSpanItems()))
+	is.Equal(buf.String(), ""+
+		"| category | name | Storage / Disk / used | Storage / Disk / free | Storage / pct |\n"+
+		"| -------- | ---- | --------------------- | --------------------- | ------------- |\n"+
+		"| ssd      | sda  | 10G                   | 90G                   | 10%           |\n"+
+		"|          | sdb  | 1G                    | 9G                    | 10%           |\n"+
+		"| hdd      | sdc  | total unknown         |                       | ?             |\n")
+}
+
+func TestSpanErrors(t *testing.T) {
+	is := is.New(t)
+	tab := newTestTable("a", "b", "c")
+	tab.AddHeaderRow(&HeaderGroup{Title: "x", Columns: []string{"a", "c"}})
+	is.Err(tab.WriteMarkdown(&bytes.Buffer{}, nil))
+
+	tab = newTestTable("a", "b", "c")
+	tab.AddCellSpan(&CellSpan{Row: 0, Column: "a", Rows: 2, Columns: 2})
+	tab.AddCellSpan(&CellSpan{Row: 1, Column: "b", Columns: 2})
+	items := []any{[]any{1, 2, 3}, []any{4, 5, 6}}
+	is.Err(tab.WriteHTML(&bytes.Buffer{}, items, nil))
+	tab.ClearCellSpans()
+	// spans are clipped to the table
+	tab.AddCellSpan(&CellSpan{Row: 1, Column: "b", Rows: 5, Columns: 5})
+	is.NotErr(tab.WriteHTML(&bytes.Buffer{}, items, nil))
+}
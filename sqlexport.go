@@ -0,0 +1,301 @@
+package table
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type SQLDialect uint8
+
+const (
+	SQLPostgres SQLDialect = iota
+	SQLMySQL
+	SQLSQLite
+)
+
+// SQLOptions are options of WriteSQL
+type SQLOptions struct {
+	TableName string
+	// BatchSize is the number of rows per INSERT statement, default 100
+	BatchSize int
+	Dialect   SQLDialect
+	// CreateTable writes a CREATE TABLE statement before INSERT statements
+	CreateTable bool
+}
+
+var (
+	timeType  = reflect.TypeOf(time.Time{})
+	bytesType = reflect.TypeOf([]byte{})
+)
+
+func (d SQLDialect) quoteIdent(name string) string {
+	if d == SQLMySQL {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func (d SQLDialect) quoteString(str string) string {
+	str = strings.ReplaceAll(str, "'", "''")
+	if d == SQLMySQL {
+		str = strings.ReplaceAll(str, `\`, `\\`)
+	}
+	return "'" + str + "'"
+}
+
+// columnType returns SQL type of a column with Go type typ (may be nil)
+func (d SQLDialect) columnType(typ reflect.Type) string {
+	if typ == nil {
+		return "TEXT"
+	}
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch typ {
+	case timeType:
+		switch d {
+		case SQLMySQL:
+			return "DATETIME(6)"
+		case SQLSQLite:
+			return "TEXT"
+		}
+		return "TIMESTAMP WITH TIME ZONE"
+	case bytesType:
+		if d == SQLPostgres {
+			return "BYTEA"
+		}
+		return "BLOB"
+	}
+	switch typ.Kind() {
+	case reflect.Bool:
+		if d == SQLSQLite {
+			return "INTEGER"
+		}
+		return "BOOLEAN"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		if d == SQLSQLite {
+			return "INTEGER"
+		}
+		return "BIGINT"
+	case reflect.Uint, reflect.Uint64:
+		// values above the maximum of BIGINT
+		if d == SQLMySQL {
+			return "DECIMAL(20,0)"
+		}
+		return "NUMERIC(20)"
+	case reflect.Float32, reflect.Float64:
+		switch d {
+		case SQLMySQL:
+			return "DOUBLE"
+		case SQLSQLite:
+			return "REAL"
+		}
+		return "DOUBLE PRECISION"
+	}
+	return "TEXT"
+}
+
+// sqlDeref returns the value pointed to by value, or nil for nil pointers
+func sqlDeref(value any) any {
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return nil
+	}
+	return rv.Interface()
+}
+
+func (d SQLDialect) timeLayout() string {
+	if d == SQLMySQL {
+		return "2006-01-02 15:04:05.999999"
+	}
+	return "2006-01-02 15:04:05.999999999-07:00"
+}
+
+// literal returns SQL literal of a value returned by Getter.Value
+func (d SQLDialect) literal(value any) string {
+	value = sqlDeref(value)
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case time.Time:
+		if d == SQLMySQL {
+			v = v.UTC()
+		}
+		return d.quoteString(v.Format(d.timeLayout()))
+	case []byte:
+		if d == SQLPostgres {
+			return `'\x` + hex.EncodeToString(v) + `'`
+		}
+		return "X'" + hex.EncodeToString(v) + "'"
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Bool:
+		if d == SQLSQLite {
+			if rv.Bool() {
+				return "1"
+			}
+			return "0"
+		}
+		if rv.Bool() {
+			return "TRUE"
+		}
+		return "FALSE"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			if d == SQLPostgres {
+				return d.quoteString(strconv.FormatFloat(f, 'g', -1, 64))
+			}
+			return "NULL"
+		}
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	case reflect.String:
+		return d.quoteString(rv.String())
+	}
+	// fmt.Sprint uses String method if value is a fmt.Stringer
+	return d.quoteString(fmt.Sprint(value))
+}
+
+func (t *Table) sqlColumnNames(d SQLDialect) string {
+	names := make([]string, t.ColumnCount())
+	for i, col := range t.Columns {
+		names[i] = d.quoteIdent(col.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// CreateTableSQL returns a CREATE TABLE statement with column types
+// inferred from Column.Type
+func (t *Table) CreateTableSQL(tableName string, dialect SQLDialect) string {
+	defs := make([]string, t.ColumnCount())
+	for i, col := range t.Columns {
+		defs[i] = "\t" + dialect.quoteIdent(col.Name) + " " + dialect.columnType(col.Type)
+	}
+	return "CREATE TABLE " + dialect.quoteIdent(tableName) + " (\n" +
+		strings.Join(defs, ",\n") + "\n);\n"
+}
+
+// WriteSQL writes items as batched INSERT statements (optionally after
+// a CREATE TABLE statement), values are taken from Getter.Value.
+// opts.TableName is required
+func (t *Table) WriteSQL(out io.Writer, items []any, opts *SQLOptions) error {
+	if opts == nil {
+		opts = &SQLOptions{}
+	}
+	if opts.TableName == "" {
+		return fmt.Errorf("table name is not set")
+	}
+	d := opts.Dialect
+	batchSize := opts.BatchSize
+	if batchSize < 1 {
+		batchSize = 100
+	}
+	if opts.CreateTable {
+		_, err := io.WriteString(out, t.CreateTableSQL(opts.TableName, d))
+		if err != nil {
+			return err
+		}
+	}
+	insert := "INSERT INTO " + d.quoteIdent(opts.TableName) +
+		" (" + t.sqlColumnNames(d) + ") VALUES\n"
+	for start := 0; start < len(items); start += batchSize {
+		end := start + batchSize
+		if end > len(items) {
+			end = len(items)
+		}
+		rows := make([]string, 0, end-start)
+		for _, item := range items[start:end] {
+			values := make([]string, t.ColumnCount())
+			for i, col := range t.Columns {
+				value, err := col.Getter.Value(item)
+				if err != nil {
+					return err
+				}
+				values[i] = d.literal(value)
+			}
+			rows = append(rows, "("+strings.Join(values, ", ")+")")
+		}
+		_, err := io.WriteString(out, insert+strings.Join(rows, ",\n")+";\n")
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var copyEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"\t", `\t`,
+	"\n", `\n`,
+	"\r", `\r`,
+)
+
+// copyValue returns a value in PostgreSQL COPY text format
+func copyValue(value any) string {
+	value = sqlDeref(value)
+	switch v := value.(type) {
+	case nil:
+		return `\N`
+	case time.Time:
+		return v.Format(SQLPostgres.timeLayout())
+	case []byte:
+		return `\\x` + hex.EncodeToString(v)
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Bool:
+		if rv.Bool() {
+			return "t"
+		}
+		return "f"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 64)
+	}
+	return copyEscaper.Replace(fmt.Sprint(value))
+}
+
+// WritePostgresCopy writes items as a PostgreSQL "COPY ... FROM stdin" block
+func (t *Table) WritePostgresCopy(out io.Writer, items []any, tableName string) error {
+	if tableName == "" {
+		return fmt.Errorf("table name is not set")
+	}
+	var sb strings.Builder
+	sb.WriteString("COPY " + SQLPostgres.quoteIdent(tableName) +
+		" (" + t.sqlColumnNames(SQLPostgres) + ") FROM stdin;\n")
+	for _, item := range items {
+		values := make([]string, t.ColumnCount())
+		for i, col := range t.Columns {
+			value, err := col.Getter.Value(item)
+			if err != nil {
+				return err
+			}
+			values[i] = copyValue(value)
+		}
+		sb.WriteString(strings.Join(values, "\t") + "\n")
+	}
+	sb.WriteString(`\.` + "\n")
+	_, err := io.WriteString(out, sb.String())
+	return err
+}
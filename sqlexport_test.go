@@ -0,0 +1,93 @@
+package table
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ilius/is/v2"
+)
+
+var (
+	sqlTestMtime = time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC)
+	sqlTestNote  = "x\ty"
+	sqlTestRows  = [][]any{
+		{"it's", int64(12), true, sqlTestMtime, &sqlTestNote},
+		{`a\b`, int64(-1), false, sqlTestMtime, (*string)(nil)},
+	}
+)
+
+func TestWriteSQL(t *testing.T) {
+	is := is.New(t)
+	tab, items := newTestTableItems([]string{"name", "size", "ok", "mtime", "note"}, sqlTestRows...)
+	for i, col := range tab.Columns {
+		col.Type = reflect.TypeOf(sqlTestRows[0][i])
+	}
+	out := &strings.Builder{}
+	is.NotErr(tab.WriteSQL(out, items, &SQLOptions{
+		TableName:   "files",
+		CreateTable: true,
+	}))
+	is.Equal(out.String(), `CREATE TABLE "files" (
+	"name" TEXT,
+	"size" BIGINT,
+	"ok" BOOLEAN,
+	"mtime" TIMESTAMP WITH TIME ZONE,
+	"note" TEXT
+);
+INSERT INTO "files" ("name", "size", "ok", "mtime", "note") VALUES
+('it''s', 12, TRUE, '2023-04-05 06:07:08+00:00', 'x	y'),
+('a\b', -1, FALSE, '2023-04-05 06:07:08+00:00', NULL);
+`)
+
+	out.Reset()
+	is.NotErr(tab.WriteSQL(out, items, &SQLOptions{
+		TableName: "files",
+		Dialect:   SQLMySQL,
+		BatchSize: 1,
+	}))
+	is.Equal(out.String(), "INSERT INTO `files` (`name`, `size`, `ok`, `mtime`, `note`) VALUES\n"+
+		"('it''s', 12, TRUE, '2023-04-05 06:07:08', 'x\ty');\n"+
+		"INSERT INTO `files` (`name`, `size`, `ok`, `mtime`, `note`) VALUES\n"+
+		"('a\\\\b', -1, FALSE, '2023-04-05 06:07:08', NULL);\n",
+	)
+
+	is.Equal(tab.CreateTableSQL("t", SQLSQLite), `CREATE TABLE "t" (
+	"name" TEXT,
+	"size" INTEGER,
+	"ok" INTEGER,
+	"mtime" TEXT,
+	"note" TEXT
+);
+`)
+
+	for dialect, typ := range map[SQLDialect]string{
+		SQLPostgres: "NUMERIC(20)",
+		SQLMySQL:    "DECIMAL(20,0)",
+		SQLSQLite:   "NUMERIC(20)",
+	} {
+		is.Equal(dialect.columnType(reflect.TypeOf(uint64(0))), typ)
+		is.Equal(dialect.literal(uint64(math.MaxUint64)), "18446744073709551615")
+	}
+
+	is.Err(tab.WriteSQL(out, items, nil))
+	is.Err(tab.WriteSQL(out, items, &SQLOptions{CreateTable: true}))
+}
+
+func TestWritePostgresCopy(t *testing.T) {
+	is := is.New(t)
+	tab, items := newTestTableItems([]string{"name", "size", "ok", "mtime", "note"}, sqlTestRows...)
+	for i, col := range tab.Columns {
+		col.Type = reflect.TypeOf(sqlTestRows[0][i])
+	}
+	out := &strings.Builder{}
+	is.NotErr(tab.WritePostgresCopy(out, items, "files"))
+	is.Equal(out.String(), `COPY "files" ("name", "size", "ok", "mtime", "note") FROM stdin;
+it's	12	t	2023-04-05 06:07:08+00:00	x\ty
+a\\b	-1	f	2023-04-05 06:07:08+00:00	\N
+\.
+`)
+	is.Err(tab.WritePostgresCopy(out, items, ""))
+}
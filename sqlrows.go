@@ -0,0 +1,196 @@
+package table
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// SQLRowsOptions are options of NewSQLRows
+type SQLRowsOptions struct {
+	// NullMarker is shown for NULL values, default is "NULL"
+	NullMarker string
+	// TimeFormat is the layout of time values, default is
+	// "2006-01-02 15:04:05"
+	TimeFormat string
+}
+
+const defaultSQLTimeFormat = "2006-01-02 15:04:05"
+
+// SQLRows reads the result of a query as table items, each item is
+// a []any of values scanned from one row (nil for NULL)
+type SQLRows struct {
+	Columns []*Column
+
+	rows       *sql.Rows
+	nullMarker string
+	spec       *TableSpec
+}
+
+// NewSQLRows creates columns from rows.ColumnTypes(), Column.Type is the
+// scan type (the value type for types like sql.NullInt64) and numeric
+// columns are right-aligned. opts may be nil
+func NewSQLRows(rows *sql.Rows, opts *SQLRowsOptions) (*SQLRows, error) {
+	if opts == nil {
+		opts = &SQLRowsOptions{}
+	}
+	sr := &SQLRows{
+		rows:       rows,
+		nullMarker: opts.NullMarker,
+		spec:       NewTableSpec(),
+	}
+	if sr.nullMarker == "" {
+		sr.nullMarker = "NULL"
+	}
+	sr.spec.TimeFormat = opts.TimeFormat
+	if sr.spec.TimeFormat == "" {
+		sr.spec.TimeFormat = defaultSQLTimeFormat
+	}
+	colTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+	titles := make([]string, len(colTypes))
+	for i, ct := range colTypes {
+		titles[i] = ct.Name()
+	}
+	sr.Columns = newTextColumns(titles)
+	for i, ct := range colTypes {
+		col := sr.Columns[i]
+		col.Type = sqlValueType(ct.ScanType())
+		col.Getter = sqlCellGetter{rows: sr, index: i}
+		if sqlNumeric(ct, col.Type) {
+			col.Alignment = AlignmentRight
+		}
+		sr.spec.AddColumn(col)
+	}
+	return sr, nil
+}
+
+// sqlValueType returns the type of the value of types like sql.NullInt64
+// and sql.Null[T] (a struct of a value and Valid), or typ itself
+func sqlValueType(typ reflect.Type) reflect.Type {
+	if typ == nil {
+		return nil
+	}
+	if typ.Kind() != reflect.Struct || typ.NumField() != 2 {
+		return typ
+	}
+	valid, ok := typ.FieldByName("Valid")
+	if !ok || valid.Type.Kind() != reflect.Bool {
+		return typ
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		if i != valid.Index[0] {
+			return typ.Field(i).Type
+		}
+	}
+	return typ
+}
+
+// sqlNumeric returns true if values of column ct are numbers
+func sqlNumeric(ct *sql.ColumnType, typ reflect.Type) bool {
+	if typ != nil {
+		switch typ.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			return true
+		}
+	}
+	// decimals are usually scanned as bytes or strings
+	name := strings.ToUpper(ct.DatabaseTypeName())
+	return strings.HasPrefix(name, "DECIMAL") || strings.HasPrefix(name, "NUMERIC")
+}
+
+// Spec returns the TableSpec of Columns, time values are formatted
+// with its TimeFormat
+func (sr *SQLRows) Spec() *TableSpec {
+	return sr.spec
+}
+
+// Table returns a new Table of Spec, with column widths of titles,
+// widths of cells are added by FormatItem
+func (sr *SQLRows) Table() *Table {
+	t := NewTable(sr.Spec())
+	widths := map[string]uint16{}
+	for _, col := range sr.Columns {
+		widths[col.Name] = visualWidth(col.Title)
+	}
+	t.UpdateWidth(widths)
+	return t
+}
+
+// ForEachItem scans the remaining rows and calls f with each item,
+// without keeping items in memory. Scanning stops if f returns an error.
+// Writers like WritePlain and WriteBordered still need all rows formatted
+// (by FormatItem) before writing, to know widths of columns
+func (sr *SQLRows) ForEachItem(f func(item any) error) error {
+	for sr.rows.Next() {
+		values := make([]any, len(sr.Columns))
+		pointers := make([]any, len(values))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := sr.rows.Scan(pointers...); err != nil {
+			return err
+		}
+		if err := f(values); err != nil {
+			return err
+		}
+	}
+	return sr.rows.Err()
+}
+
+// Items scans all remaining rows
+func (sr *SQLRows) Items() ([]any, error) {
+	items := []any{}
+	err := sr.ForEachItem(func(item any) error {
+		items = append(items, item)
+		return nil
+	})
+	return items, err
+}
+
+// sqlCellGetter is a Getter of column index of SQLRows items
+type sqlCellGetter struct {
+	rows  *SQLRows
+	index int
+}
+
+func (g sqlCellGetter) Value(item any) (any, error) {
+	row, ok := item.([]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid item type %T, must be []any", item)
+	}
+	if g.index >= len(row) {
+		return nil, nil
+	}
+	return row[g.index], nil
+}
+
+func (g sqlCellGetter) ValueString(colName string, item any) (string, error) {
+	value, err := g.Value(item)
+	if err != nil {
+		return "", err
+	}
+	return g.Format(item, value)
+}
+
+func (g sqlCellGetter) Format(item any, value any) (string, error) {
+	switch value := value.(type) {
+	case nil:
+		return g.rows.nullMarker, nil
+	case []byte:
+		return string(value), nil
+	case time.Time:
+		layout := g.rows.spec.TimeFormat
+		if layout == "" {
+			layout = defaultSQLTimeFormat
+		}
+		return value.Format(layout), nil
+	}
+	return fmt.Sprint(value), nil
+}
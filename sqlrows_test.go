@@ -0,0 +1,193 @@
+package table
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ilius/is/v2"
+)
+
+// fakeColumn is a column of fakeRows
+type fakeColumn struct {
+	name     string
+	typeName string
+	scanType reflect.Type
+}
+
+// fakeDriver is an in-process driver.Driver and driver.Connector,
+// every query returns columns and rows
+type fakeDriver struct {
+	columns []fakeColumn
+	rows    [][]driver.Value
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	return &fakeConn{driver: d}, nil
+}
+
+func (d *fakeDriver) Connect(ctx context.Context) (driver.Conn, error) {
+	return &fakeConn{driver: d}, nil
+}
+
+func (d *fakeDriver) Driver() driver.Driver {
+	return d
+}
+
+type fakeConn struct {
+	driver *fakeDriver
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{driver: c.driver}, nil
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+type fakeStmt struct {
+	driver *fakeDriver
+}
+
+func (s *fakeStmt) Close() error {
+	return nil
+}
+
+func (s *fakeStmt) NumInput() int {
+	return -1
+}
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("exec is not supported")
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &fakeRows{driver: s.driver}, nil
+}
+
+type fakeRows struct {
+	driver *fakeDriver
+	next   int
+}
+
+func (r *fakeRows) Columns() []string {
+	names := make([]string, len(r.driver.columns))
+	for i, col := range r.driver.columns {
+		names[i] = col.name
+	}
+	return names
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.driver.rows) {
+		return io.EOF
+	}
+	copy(dest, r.driver.rows[r.next])
+	r.next++
+	return nil
+}
+
+func (r *fakeRows) ColumnTypeScanType(index int) reflect.Type {
+	return r.driver.columns[index].scanType
+}
+
+func (r *fakeRows) ColumnTypeDatabaseTypeName(index int) string {
+	return r.driver.columns[index].typeName
+}
+
+func queryFakeRows(is *is.Is) *sql.Rows {
+	db := sql.OpenDB(&fakeDriver{
+		columns: []fakeColumn{
+			{"id", "BIGINT", reflect.TypeOf(int64(0))},
+			{"name", "TEXT", reflect.TypeOf(sql.NullString{})},
+			{"price", "DECIMAL(10,2)", reflect.TypeOf(sql.RawBytes{})},
+			{"stock", "INTEGER", reflect.TypeOf(sql.NullInt64{})},
+			{"added", "TIMESTAMP", reflect.TypeOf(sql.NullTime{})},
+		},
+		rows: [][]driver.Value{
+			{int64(1), "pen", []byte("1.50"), int64(120), time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)},
+			{int64(2), nil, []byte("12.00"), nil, nil},
+			{int64(10), "notebook", nil, int64(7), time.Date(2024, 3, 2, 18, 5, 9, 0, time.UTC)},
+		},
+	})
+	rows, err := db.Query("SELECT * FROM products")
+	is.NotErr(err)
+	return rows
+}
+
+func TestSQLRows(t *testing.T) {
+	is := is.New(t)
+	rows := queryFakeRows(is)
+	defer rows.Close()
+	sr, err := NewSQLRows(rows, &SQLRowsOptions{NullMarker: "∅"})
+	is.NotErr(err)
+	types := []reflect.Type{}
+	for _, col := range sr.Columns {
+		types = append(types, col.Type)
+	}
+	is.Equal(types, []reflect.Type{
+		int64Type,
+		stringType,
+		reflect.TypeOf(sql.RawBytes{}),
+		int64Type,
+		timeType,
+	})
+	is.Equal(alignmentNames(sr.Columns), []string{"right", "left", "right", "right", "left"})
+
+	tab := sr.Table()
+	tab.SetColor(false)
+	formatted := FormattedItems{}
+	is.NotErr(sr.ForEachItem(func(item any) error {
+		row, err := tab.FormatItem(item)
+		formatted = append(formatted, row)
+		return err
+	}))
+	buf := &bytes.Buffer{}
+	is.NotErr(tab.WritePlain(buf, formatted, "  "))
+	is.Equal(buf.String(), ""+
+		"id    name    price  stock         added       \n"+
+		" 1  pen        1.50    120  2024-03-01 09:30:00\n"+
+		" 2  ∅         12.00      ∅  ∅                  \n"+
+		"10  notebook      ∅      7  2024-03-02 18:05:09\n")
+
+	value, err := sr.Columns[3].Getter.Value(formatted)
+	is.Err(err)
+	is.Equal(value, nil)
+
+	tab.TimeFormat = "Jan 2"
+	str, err := sr.Columns[4].Getter.ValueString("added", []any{nil, nil, nil, nil, time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)})
+	is.NotErr(err)
+	is.Equal(str, "Mar 1")
+}
+
+func TestSQLRowsItems(t *testing.T) {
+	is := is.New(t)
+	rows := queryFakeRows(is)
+	defer rows.Close()
+	sr, err := NewSQLRows(rows, nil)
+	is.NotErr(err)
+	items, err := sr.Items()
+	is.NotErr(err)
+	is.Equal(len(items), 3)
+	str, err := sr.Columns[1].Getter.ValueString("name", items[1])
+	is.NotErr(err)
+	is.Equal(str, "NULL")
+	value, err := sr.Columns[2].Getter.Value(items[0])
+	is.NotErr(err)
+	is.Equal(value, []byte("1.50"))
+}
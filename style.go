@@ -0,0 +1,478 @@
+package table
+
+import (
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	sgrStart = "\x1b["
+	sgrReset = "\x1b[0m"
+)
+
+type colorMode uint8
+
+const (
+	colorModeDefault colorMode = iota
+	colorMode16
+	colorMode256
+	colorModeRGB
+)
+
+// Color is a terminal color, zero value is the terminal's default color
+type Color struct {
+	mode  colorMode
+	value uint32
+}
+
+// Color16 returns one of the 16 basic terminal colors (0-7 normal, 8-15 bright)
+func Color16(code uint8) Color {
+	return Color{mode: colorMode16, value: uint32(code & 15)}
+}
+
+// Color256 returns a color from the 256-color (8-bit) palette
+func Color256(code uint8) Color {
+	return Color{mode: colorMode256, value: uint32(code)}
+}
+
+// RGB returns a 24-bit (truecolor) color
+func RGB(r, g, b uint8) Color {
+	return Color{mode: colorModeRGB, value: uint32(r)<<16 | uint32(g)<<8 | uint32(b)}
+}
+
+var (
+	Black   = Color16(0)
+	Red     = Color16(1)
+	Green   = Color16(2)
+	Yellow  = Color16(3)
+	Blue    = Color16(4)
+	Magenta = Color16(5)
+	Cyan    = Color16(6)
+	White   = Color16(7)
+)
+
+func (c Color) IsDefault() bool {
+	return c.mode == colorModeDefault
+}
+
+// xterm default values for the 16 basic colors
+var basicPalette = [16][3]uint8{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+func (c Color) rgb() (uint8, uint8, uint8) {
+	switch c.mode {
+	case colorMode16:
+		p := basicPalette[c.value]
+		return p[0], p[1], p[2]
+	case colorMode256:
+		n := c.value
+		if n < 16 {
+			p := basicPalette[n]
+			return p[0], p[1], p[2]
+		}
+		if n >= 232 {
+			v := uint8(8 + (n-232)*10)
+			return v, v, v
+		}
+		n -= 16
+		return cubeLevels[n/36], cubeLevels[n/6%6], cubeLevels[n%6]
+	case colorModeRGB:
+		return uint8(c.value >> 16), uint8(c.value >> 8), uint8(c.value)
+	}
+	return 0, 0, 0
+}
+
+// sgrParams returns SGR parameters, base is 30 for foreground and 40 for background
+func (c Color) sgrParams(base int) string {
+	switch c.mode {
+	case colorMode16:
+		if c.value < 8 {
+			return strconv.Itoa(base + int(c.value))
+		}
+		return strconv.Itoa(base + 60 + int(c.value) - 8)
+	case colorMode256:
+		return strconv.Itoa(base+8) + ";5;" + strconv.Itoa(int(c.value))
+	case colorModeRGB:
+		r, g, b := c.rgb()
+		return strconv.Itoa(base+8) + ";2;" +
+			strconv.Itoa(int(r)) + ";" +
+			strconv.Itoa(int(g)) + ";" +
+			strconv.Itoa(int(b))
+	}
+	return ""
+}
+
+// Style is a set of SGR attributes applied to a cell or a row
+type Style struct {
+	Fg        Color
+	Bg        Color
+	Bold      bool
+	Underline bool
+	Inverse   bool
+}
+
+func (s Style) IsZero() bool {
+	return s == Style{}
+}
+
+// merge returns s with non-zero attributes of o overriding those of s
+func (s Style) merge(o Style) Style {
+	if !o.Fg.IsDefault() {
+		s.Fg = o.Fg
+	}
+	if !o.Bg.IsDefault() {
+		s.Bg = o.Bg
+	}
+	s.Bold = s.Bold || o.Bold
+	s.Underline = s.Underline || o.Underline
+	s.Inverse = s.Inverse || o.Inverse
+	return s
+}
+
+func (s Style) sgr(level ColorLevel) string {
+	if level == ColorLevelNone {
+		return ""
+	}
+	params := make([]string, 0, 5)
+	if s.Bold {
+		params = append(params, "1")
+	}
+	if s.Underline {
+		params = append(params, "4")
+	}
+	if s.Inverse {
+		params = append(params, "7")
+	}
+	if fg := s.Fg.downgrade(level); !fg.IsDefault() {
+		params = append(params, fg.sgrParams(30))
+	}
+	if bg := s.Bg.downgrade(level); !bg.IsDefault() {
+		params = append(params, bg.sgrParams(40))
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	return sgrStart + strings.Join(params, ";") + "m"
+}
+
+// Apply wraps str in the SGR sequences of style
+func (s Style) Apply(str string) string {
+	return s.apply(str, ColorLevelTrue)
+}
+
+// apply wraps str in the SGR sequences of style, with colors downgraded
+// to level. Style is re-applied after every reset inside str, so that
+// a row background is not interrupted by colored cells.
+func (s Style) apply(str string, level ColorLevel) string {
+	start := s.sgr(level)
+	if start == "" {
+		return str
+	}
+	var sb strings.Builder
+	sb.WriteString(start)
+	ForEachToken(str, func(tok Token) {
+		sb.WriteString(tok.Text)
+		if tok.Kind == TokenCSI && isSGRReset(tok) {
+			sb.WriteString(start)
+		}
+	})
+	sb.WriteString(sgrReset)
+	return sb.String()
+}
+
+// StyleRule styles a cell (or the whole row if Row is true) based on the
+// value that Getter.Value returns for column Column.
+// Match == nil matches all values. If StyleFunc is set, it is used instead
+// of Style to compute the style from value (for example Gradient).
+type StyleRule struct {
+	Match     func(value any) bool
+	StyleFunc func(value any) Style
+	Column    string
+	Style     Style
+	Row       bool
+}
+
+func (r *StyleRule) styleOf(value any) (Style, bool) {
+	if r.Match != nil && !r.Match(value) {
+		return Style{}, false
+	}
+	if r.StyleFunc != nil {
+		return r.StyleFunc(value), true
+	}
+	return r.Style, true
+}
+
+// Gradient returns a StyleRule.StyleFunc that sets the foreground color
+// by linear interpolation between from (at low) and to (at high) for
+// numeric values (time.Duration is taken in seconds)
+func Gradient(low float64, high float64, from Color, to Color) func(value any) Style {
+	r1, g1, b1 := from.rgb()
+	r2, g2, b2 := to.rgb()
+	lerp := func(a uint8, b uint8, f float64) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*f + 0.5)
+	}
+	return func(value any) Style {
+		x, ok := toFloat64(value)
+		if !ok || high <= low {
+			return Style{}
+		}
+		f := (x - low) / (high - low)
+		if f < 0 {
+			f = 0
+		} else if f > 1 {
+			f = 1
+		}
+		return Style{Fg: RGB(lerp(r1, r2, f), lerp(g1, g2, f), lerp(b1, b2, f))}
+	}
+}
+
+func toFloat64(value any) (float64, bool) {
+	switch v := value.(type) {
+	case time.Duration:
+		return v.Seconds(), true
+	case float64:
+		return v, true
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// ColorLevel is the color capability of a terminal, colors of a higher
+// level are downgraded to the nearest color of the terminal's level
+type ColorLevel uint8
+
+const (
+	ColorLevelNone ColorLevel = iota
+	ColorLevel16
+	ColorLevel256
+	ColorLevelTrue
+)
+
+// DetectColorLevel returns ColorLevelNone if ColorEnabled(out) is false,
+// otherwise detects the level from COLORTERM and TERM environment variables
+func DetectColorLevel(out io.Writer) ColorLevel {
+	if !ColorEnabled(out) {
+		return ColorLevelNone
+	}
+	switch os.Getenv("COLORTERM") {
+	case "truecolor", "24bit":
+		return ColorLevelTrue
+	}
+	term := os.Getenv("TERM")
+	if strings.Contains(term, "256color") {
+		return ColorLevel256
+	}
+	if term == "dumb" {
+		return ColorLevelNone
+	}
+	return ColorLevel16
+}
+
+func (c Color) downgrade(level ColorLevel) Color {
+	switch level {
+	case ColorLevelNone:
+		return Color{}
+	case ColorLevel16:
+		if c.mode == colorMode256 || c.mode == colorModeRGB {
+			return nearestColor16(c.rgb())
+		}
+	case ColorLevel256:
+		if c.mode == colorModeRGB {
+			return nearestColor256(c.rgb())
+		}
+	}
+	return c
+}
+
+func colorDistance(r1, g1, b1, r2, g2, b2 uint8) int {
+	dr := int(r1) - int(r2)
+	dg := int(g1) - int(g2)
+	db := int(b1) - int(b2)
+	return dr*dr + dg*dg + db*db
+}
+
+func nearestColor16(r, g, b uint8) Color {
+	best, bestDist := 0, -1
+	for i, p := range basicPalette {
+		dist := colorDistance(r, g, b, p[0], p[1], p[2])
+		if bestDist < 0 || dist < bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	return Color16(uint8(best))
+}
+
+func nearestCubeIndex(v uint8) int {
+	best, bestDist := 0, -1
+	for i, level := range cubeLevels {
+		dist := int(v) - int(level)
+		if dist < 0 {
+			dist = -dist
+		}
+		if bestDist < 0 || dist < bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	return best
+}
+
+func nearestColor256(r, g, b uint8) Color {
+	cube := Color256(uint8(16 + 36*nearestCubeIndex(r) + 6*nearestCubeIndex(g) + nearestCubeIndex(b)))
+	grayIndex := (int(r)+int(g)+int(b))/3 - 8
+	if grayIndex < 0 {
+		grayIndex = 0
+	}
+	grayIndex /= 10
+	if grayIndex > 23 {
+		grayIndex = 23
+	}
+	gray := Color256(uint8(232 + grayIndex))
+	cr, cg, cb := cube.rgb()
+	gr, gg, gb := gray.rgb()
+	if colorDistance(r, g, b, gr, gg, gb) < colorDistance(r, g, b, cr, cg, cb) {
+		return gray
+	}
+	return cube
+}
+
+// ColorEnabled reports whether colored output should be written to out.
+// It follows NO_COLOR (https://no-color.org) and CLICOLOR / CLICOLOR_FORCE
+// (https://bixense.com/clicolors) conventions, in this order:
+// NO_COLOR set: false, CLICOLOR_FORCE set and not "0": true,
+// CLICOLOR == "0": false, otherwise: whether out is a terminal
+func ColorEnabled(out io.Writer) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if force := os.Getenv("CLICOLOR_FORCE"); force != "" && force != "0" {
+		return true
+	}
+	if os.Getenv("CLICOLOR") == "0" {
+		return false
+	}
+	return isTerminal(out)
+}
+
+func isTerminal(out io.Writer) bool {
+	f, ok := out.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// styleItem applies style rules to formatted cells of one item
+// values are the results of Getter.Value for each column
+func (t *Table) styleItem(formatted []string, values []any) {
+	var rowStyle Style
+	cellStyle := make([]Style, len(formatted))
+	for _, rule := range t.StyleRules {
+		colI := t.columnIndex(rule.Column)
+		if colI < 0 {
+			continue
+		}
+		style, ok := rule.styleOf(values[colI])
+		if !ok {
+			continue
+		}
+		if rule.Row {
+			rowStyle = rowStyle.merge(style)
+			continue
+		}
+		cellStyle[colI] = cellStyle[colI].merge(style)
+	}
+	for i := range formatted {
+		formatted[i] = rowStyle.merge(cellStyle[i]).apply(formatted[i], t.outputColorLevel())
+	}
+}
+
+// applySGR returns style updated by SGR parameters params ("1;31" of
+// "ESC [ 1;31 m"), unsupported parameters are ignored
+func applySGR(style Style, params string) Style {
+	if params == "" {
+		return Style{}
+	}
+	parts := strings.FieldsFunc(params, func(r rune) bool {
+		return r == ';' || r == ':'
+	})
+	codes := make([]int, len(parts))
+	for i, part := range parts {
+		codes[i], _ = strconv.Atoi(part)
+	}
+	// extendedColor parses "5;n" or "2;r;g;b" after 38 or 48
+	extendedColor := func(i int) (Color, int) {
+		if i+1 < len(codes) && codes[i] == 5 {
+			return Color256(uint8(codes[i+1])), i + 1
+		}
+		if i+3 < len(codes) && codes[i] == 2 {
+			return RGB(uint8(codes[i+1]), uint8(codes[i+2]), uint8(codes[i+3])), i + 3
+		}
+		return Color{}, len(codes)
+	}
+	for i := 0; i < len(codes); i++ {
+		code := codes[i]
+		switch {
+		case code == 0:
+			style = Style{}
+		case code == 1:
+			style.Bold = true
+		case code == 4:
+			style.Underline = true
+		case code == 7:
+			style.Inverse = true
+		case code == 22:
+			style.Bold = false
+		case code == 24:
+			style.Underline = false
+		case code == 27:
+			style.Inverse = false
+		case code >= 30 && code <= 37:
+			style.Fg = Color16(uint8(code - 30))
+		case code == 38:
+			style.Fg, i = extendedColor(i + 1)
+		case code == 39:
+			style.Fg = Color{}
+		case code >= 40 && code <= 47:
+			style.Bg = Color16(uint8(code - 40))
+		case code == 48:
+			style.Bg, i = extendedColor(i + 1)
+		case code == 49:
+			style.Bg = Color{}
+		case code >= 90 && code <= 97:
+			style.Fg = Color16(uint8(code - 90 + 8))
+		case code >= 100 && code <= 107:
+			style.Bg = Color16(uint8(code - 100 + 8))
+		}
+	}
+	return style
+}
+
+// sgrTokenParams returns parameters of a SGR token ("1;31" for "ESC [ 1;31 m")
+func sgrTokenParams(tok Token) (string, bool) {
+	if !isSGR(tok) {
+		return "", false
+	}
+	return controlParams(tok.Text[:len(tok.Text)-1], c1CSI, '[')
+}
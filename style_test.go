@@ -0,0 +1,93 @@
+package table
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestStyleRules(t *testing.T) {
+	is := is.New(t)
+	tab := newTestTable("name", "status")
+	tab.AddStyleRule(&StyleRule{
+		Column: "status",
+		Match: func(value any) bool {
+			return value == "failed"
+		},
+		Style: Style{Fg: Red},
+	})
+	tab.AddStyleRule(&StyleRule{
+		Column: "status",
+		Match: func(value any) bool {
+			return value == "failed"
+		},
+		Style: Style{Bold: true},
+		Row:   true,
+	})
+
+	tab.SetColor(false)
+	formatted, err := tab.FormatItem([]any{"job1", "failed"})
+	is.NotErr(err)
+	is.Equal(formatted, []string{"job1", "failed"})
+
+	tab.SetColor(true)
+	formatted, err = tab.FormatItem([]any{"job2", "failed"})
+	is.NotErr(err)
+	is.Equal(formatted, []string{
+		"\x1b[1mjob2\x1b[0m",
+		"\x1b[1;31mfailed\x1b[0m",
+	})
+	is.Equal(tab.Width("status"), uint16(6))
+
+	formatted, err = tab.FormatItem([]any{"job3", "ok"})
+	is.NotErr(err)
+	is.Equal(formatted, []string{"job3", "ok"})
+}
+
+func TestGradient(t *testing.T) {
+	is := is.New(t)
+	grad := Gradient(0, 100, RGB(0, 0, 0), RGB(200, 100, 0))
+	is.Equal(grad(50), Style{Fg: RGB(100, 50, 0)})
+	is.Equal(grad(150.0), Style{Fg: RGB(200, 100, 0)})
+	is.Equal(grad("x"), Style{})
+}
+
+func TestStripColors(t *testing.T) {
+	is := is.New(t)
+	tab := newTestTable("name")
+	tab.AddStyleRule(&StyleRule{Column: "name", Style: Style{Bold: true}})
+	tab.SetColor(true)
+	tab.SetStripColors(true)
+	formatted, err := tab.FormatItem([]any{Fg(1) + "さの" + reset + ".png"})
+	is.NotErr(err)
+	is.Equal(formatted, []string{"さの.png"})
+	is.Equal(tab.Width("name"), uint16(8))
+
+	// theme styles are not applied either
+	tab.SetTheme(ThemeMonochrome)
+	items := FormattedItems{formatted, formatted}
+	out := &strings.Builder{}
+	is.NotErr(tab.WritePlain(out, items, " "))
+	is.NotErr(tab.WriteBordered(out, items, BorderLight))
+	is.False(strings.Contains(out.String(), "\x1b"))
+}
+
+func TestColorEnabled(t *testing.T) {
+	is := is.New(t)
+	out := &strings.Builder{}
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("CLICOLOR", "")
+	t.Setenv("CLICOLOR_FORCE", "")
+	is.False(ColorEnabled(out))
+	t.Setenv("CLICOLOR_FORCE", "1")
+	is.True(ColorEnabled(out))
+	t.Setenv("NO_COLOR", "1")
+	is.False(ColorEnabled(out))
+
+	tab := newTestTable("name")
+	tab.DetectOutput(out)
+	formatted, err := tab.FormatItem([]any{Fg(1) + "x" + reset})
+	is.NotErr(err)
+	is.Equal(formatted, []string{"x"})
+}
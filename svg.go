@@ -0,0 +1,153 @@
+package table
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// ImageOptions are options of RenderSVG and RenderPNG
+type ImageOptions struct {
+	// Foreground and Background are default colors, defaults are
+	// light gray on dark gray
+	Foreground Color
+	Background Color
+	// FontSize of SVG text in pixels, default 14.
+	// Cells are 0.6 * FontSize wide and 1.2 * FontSize high
+	FontSize int
+	// Scale of PNG glyphs (bitmap font cells are 6x10 pixels), default 2
+	Scale int
+}
+
+func (opts *ImageOptions) colors() (Color, Color) {
+	fg, bg := opts.Foreground, opts.Background
+	if fg.IsDefault() {
+		fg = RGB(0xd4, 0xd4, 0xd4)
+	}
+	if bg.IsDefault() {
+		bg = RGB(0x1e, 0x1e, 0x1e)
+	}
+	return fg, bg
+}
+
+// svgRound rounds SVG coordinates to 2 decimal places
+func svgRound(f float64) float64 {
+	return math.Round(f*100) / 100
+}
+
+// svgSegment is a run of cells in a line with the same style and width
+type svgSegment struct {
+	text  string
+	style Style
+	col   int
+	cells int
+	width int
+}
+
+func svgSegments(row []screenCell) []svgSegment {
+	segments := []svgSegment{}
+	for col, cell := range row {
+		if cell.cont {
+			continue
+		}
+		if n := len(segments); n > 0 {
+			last := &segments[n-1]
+			if last.style == cell.style && last.width == cell.width && last.col+last.cells == col {
+				last.text += cell.text
+				last.cells += cell.width
+				continue
+			}
+		}
+		segments = append(segments, svgSegment{
+			text:  cell.text,
+			style: cell.style,
+			col:   col,
+			cells: cell.width,
+			width: cell.width,
+		})
+	}
+	return segments
+}
+
+// RenderSVG renders terminal output text (with SGR colors, bold and
+// underline) as an SVG image on a monospace cell grid, wide (CJK)
+// characters take two cells as they do in the terminal
+func RenderSVG(out io.Writer, text string, opts *ImageOptions) error {
+	if opts == nil {
+		opts = &ImageOptions{}
+	}
+	fontSize := opts.FontSize
+	if fontSize <= 0 {
+		fontSize = 14
+	}
+	cellW := svgRound(float64(fontSize) * 0.6)
+	cellH := svgRound(float64(fontSize) * 1.2)
+	defaultFg, defaultBg := opts.colors()
+	grid := parseScreen(text)
+	cols, rows := screenSize(grid)
+	width := svgRound(float64(cols+2) * cellW)
+	height := svgRound(float64(rows+1) * cellH)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%g" height="%g" viewBox="0 0 %g %g">`+"\n",
+		width, height, width, height)
+	fmt.Fprintf(&sb, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", htmlColor(defaultBg))
+	fmt.Fprintf(&sb, `<g font-family="monospace" font-size="%d" xml:space="preserve">`+"\n", fontSize)
+	for rowI, row := range grid {
+		y := svgRound((float64(rowI) + 0.5) * cellH)
+		for _, seg := range svgSegments(row) {
+			x := svgRound((float64(seg.col) + 1) * cellW)
+			segW := svgRound(float64(seg.cells) * cellW)
+			fg, bg := seg.style.colors(defaultFg, defaultBg)
+			if bg != defaultBg {
+				fmt.Fprintf(&sb, `<rect x="%g" y="%g" width="%g" height="%g" fill="%s"/>`+"\n",
+					x, y, segW, cellH, htmlColor(bg))
+			}
+			if strings.TrimSpace(seg.text) == "" && !seg.style.Underline {
+				continue
+			}
+			attrs := fmt.Sprintf(`x="%g" y="%g" fill="%s"`, x, svgRound(y+cellH*0.8), htmlColor(fg))
+			if seg.style.Bold {
+				attrs += ` font-weight="bold"`
+			}
+			if seg.style.Underline {
+				attrs += ` text-decoration="underline"`
+			}
+			// force the width of the text to match the cell grid
+			attrs += fmt.Sprintf(` textLength="%g" lengthAdjust="spacingAndGlyphs"`, segW)
+			sb.WriteString("<text " + attrs + ">" + xmlEscape(seg.text) + "</text>\n")
+		}
+	}
+	sb.WriteString("</g>\n</svg>\n")
+	_, err := io.WriteString(out, sb.String())
+	return err
+}
+
+// renderTable writes the table with border (or WritePlain if border is nil)
+// into a string, with colors enabled
+func (t *Table) renderTable(items FormattedItemList, border *Border) (string, error) {
+	buf := &bytes.Buffer{}
+	colorLevel, stripColors := t.colorLevel, t.stripColors
+	t.colorLevel, t.stripColors = ColorLevelTrue, false
+	defer func() {
+		t.colorLevel, t.stripColors = colorLevel, stripColors
+	}()
+	var err error
+	if border == nil {
+		err = t.WritePlain(buf, items, innerSep)
+	} else {
+		err = t.WriteBordered(buf, items, border)
+	}
+	return buf.String(), err
+}
+
+// WriteSVG renders the table as an SVG image, see RenderSVG.
+// If border is nil, the table is rendered without borders
+func (t *Table) WriteSVG(out io.Writer, items FormattedItemList, border *Border, opts *ImageOptions) error {
+	text, err := t.renderTable(items, border)
+	if err != nil {
+		return err
+	}
+	return RenderSVG(out, text, opts)
+}
@@ -2,7 +2,9 @@ package table
 
 import (
 	"fmt"
+	"io"
 	"reflect"
+	"strings"
 )
 
 const (
@@ -19,12 +21,20 @@ type Column struct {
 	Title     string
 
 	ShortTitle string
+
+	// XMLAttribute makes WriteXML write the column as an attribute of
+	// item elements, instead of a child element
+	XMLAttribute bool
 }
 
 type TableSpec struct {
 	ColumnByName map[string]*Column
 	TimeFormat   string
 	Columns      []*Column
+	StyleRules   []*StyleRule
+	// HeaderRows are rows of grouped titles above column titles,
+	// the first row is the top one
+	HeaderRows [][]*HeaderGroup
 }
 
 func (t *TableSpec) HasColumn(colName string) bool {
@@ -36,6 +46,19 @@ func (t *TableSpec) AddColumn(col *Column) {
 	t.ColumnByName[col.Name] = col
 }
 
+func (t *TableSpec) AddStyleRule(rule *StyleRule) {
+	t.StyleRules = append(t.StyleRules, rule)
+}
+
+func (t *TableSpec) columnIndex(colName string) int {
+	for i, col := range t.Columns {
+		if col.Name == colName {
+			return i
+		}
+	}
+	return -1
+}
+
 func (t *TableSpec) ColumnCount() int {
 	return len(t.Columns)
 }
@@ -44,6 +67,11 @@ type Table struct {
 	*TableSpec
 	columnWidth map[string]uint16
 	// Data        []any
+	Theme       *Theme
+	colorLevel  ColorLevel
+	hyperlinks  bool
+	stripColors bool
+	spans       []*CellSpan
 }
 
 func NewTableSpec() *TableSpec {
@@ -53,6 +81,9 @@ func NewTableSpec() *TableSpec {
 	}
 }
 
+// NewTable returns a table of spec, colors (StyleRules and Theme styles)
+// and hyperlinks are disabled until they are enabled for the output,
+// see DetectOutput
 func NewTable(spec *TableSpec) *Table {
 	if spec == nil {
 		spec = NewTableSpec()
@@ -63,6 +94,51 @@ func NewTable(spec *TableSpec) *Table {
 	}
 }
 
+// SetColor enables (with truecolor) or disables applying StyleRules and Theme
+func (t *Table) SetColor(enabled bool) {
+	if enabled {
+		t.colorLevel = ColorLevelTrue
+		return
+	}
+	t.colorLevel = ColorLevelNone
+}
+
+// SetColorLevel sets the color capability level used for StyleRules and Theme
+func (t *Table) SetColorLevel(level ColorLevel) {
+	t.colorLevel = level
+}
+
+// DetectColor sets the color level based on out, see DetectColorLevel
+func (t *Table) DetectColor(out io.Writer) {
+	t.colorLevel = DetectColorLevel(out)
+}
+
+// SetStripColors enables or disables removing all escape sequences
+// (including those produced by getters) from cells in FormatItem, and
+// disables StyleRules, Theme styles and hyperlinks while enabled.
+// Alignment is not affected since escape sequences have no width
+func (t *Table) SetStripColors(enabled bool) {
+	t.stripColors = enabled
+}
+
+// outputColorLevel returns the color level of styles added by the table,
+// ColorLevelNone if escape sequences are stripped
+func (t *Table) outputColorLevel() ColorLevel {
+	if t.stripColors {
+		return ColorLevelNone
+	}
+	return t.colorLevel
+}
+
+// DetectOutput configures colors, hyperlinks and stripping based on out
+// and the environment, see ColorEnabled and HyperlinksEnabled.
+// Escape sequences are stripped from cells if colors are disabled
+func (t *Table) DetectOutput(out io.Writer) {
+	t.colorLevel = DetectColorLevel(out)
+	t.hyperlinks = HyperlinksEnabled(out)
+	t.stripColors = t.colorLevel == ColorLevelNone
+}
+
 func (t *Table) UpdateWidth(widthByColumn map[string]uint16) {
 	for colName, width := range widthByColumn {
 		if width > t.columnWidth[colName] {
@@ -92,14 +168,67 @@ func (t *Table) FormatItemBasic(item any, sep string) (string, error) {
 	return str, nil
 }
 
+// textCells returns column titles and results of Getter.Format for each
+// item, with escape sequences removed, line breaks replaced by spaces and
+// then escaped by escape (if not nil), and the visual width of each column.
+// It is used by text-based markup writers, column widths of the table
+// are not changed
+func (t *Table) textCells(items []any, escape func(string) string) (header []string, rows [][]string, widths []uint16, err error) {
+	colN := t.ColumnCount()
+	if escape == nil {
+		escape = func(str string) string { return str }
+	}
+	widths = make([]uint16, colN)
+	updateWidths := func(row []string) {
+		for i, cell := range row {
+			if w := visualWidth(cell); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+	header = make([]string, colN)
+	for i, col := range t.Columns {
+		header[i] = escape(col.Title)
+	}
+	updateWidths(header)
+	rows = make([][]string, 0, len(items))
+	for _, item := range items {
+		row := make([]string, colN)
+		for i, col := range t.Columns {
+			value, err := col.Getter.Value(item)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			formatted, err := col.Getter.Format(item, value)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			row[i] = escape(strings.ReplaceAll(StripANSI(formatted), "\n", " "))
+		}
+		updateWidths(row)
+		rows = append(rows, row)
+	}
+	return header, rows, widths, nil
+}
+
 func (t *Table) FormatItem(item any) ([]string, error) {
-	cw := t.columnWidth
-	formatted := make([]string, t.ColumnCount())
+	values := make([]any, t.ColumnCount())
 	for i, col := range t.Columns {
 		value, err := col.Getter.Value(item)
 		if err != nil {
 			return nil, err
 		}
+		values[i] = value
+	}
+	return t.formatValues(item, values)
+}
+
+// formatValues formats values of columns of item, see FormatItem
+func (t *Table) formatValues(item any, values []any) ([]string, error) {
+	cw := t.columnWidth
+	formatted := make([]string, t.ColumnCount())
+	for i, col := range t.Columns {
+		value := values[i]
 		//if reflect.TypeOf(value) != col.Type {
 		//	fmt.Fprintf(os.Stderr, "invalid type %T for column %v, must be %v\n", value, col.Name, col.Type)
 		//}
@@ -107,6 +236,9 @@ func (t *Table) FormatItem(item any) ([]string, error) {
 		if err != nil {
 			return nil, err
 		}
+		if t.stripColors {
+			valueFormatted = StripANSI(valueFormatted)
+		}
 		formatted[i] = valueFormatted
 		// even if col.Alignment == nil, we may need it for MergeRows* funcs
 		width := visualWidth(valueFormatted)
@@ -114,6 +246,24 @@ func (t *Table) FormatItem(item any) ([]string, error) {
 			cw[col.Name] = width
 		}
 	}
+	if t.stripColors {
+		return formatted, nil
+	}
+	// styles are applied after measuring the width, SGR sequences are invisible
+	if t.colorLevel != ColorLevelNone && len(t.StyleRules) > 0 {
+		t.styleItem(formatted, values)
+	}
+	if t.hyperlinks {
+		for i, col := range t.Columns {
+			link, err := columnLink(col, item)
+			if err != nil {
+				return nil, err
+			}
+			if link != "" {
+				formatted[i] = Hyperlink(link, formatted[i])
+			}
+		}
+	}
 	return formatted, nil
 }
 
@@ -134,7 +284,12 @@ func (t *Table) AlignFormattedItem(formatted []string) ([]string, error) {
 }
 
 func (t *Table) padColumnHeader(col *Column) string {
-	width := t.Width(col.Name)
+	return t.padTitle(col, t.Width(col.Name))
+}
+
+// padTitle centers Title (or ShortTitle if Title is wider) of col
+// in width
+func (t *Table) padTitle(col *Column, width uint16) string {
 	value := col.Title
 	if width == 0 {
 		return value
@@ -149,15 +304,35 @@ func (t *Table) padColumnHeader(col *Column) string {
 	return AlignmentCenter(value, width)
 }
 
+// headerCells returns padded column titles, styled by Theme.Header
+func (t *Table) headerCells() []string {
+	headerStyle := t.themeStyle(func(theme *Theme) Style { return theme.Header })
+	cells := make([]string, t.ColumnCount())
+	for i, col := range t.Columns {
+		cells[i] = headerStyle.apply(t.padColumnHeader(col), t.outputColorLevel())
+	}
+	return cells
+}
+
 func (t *Table) FormatHeader(sep string) string {
 	str := ""
-	for _, col := range t.Columns {
-		str += t.padColumnHeader(col) + sep
+	for _, cell := range t.headerCells() {
+		str += cell + sep
 	}
 	str += "\n"
 	return str
 }
 
+// alignCell aligns str using Alignment of column colI, or AlignmentLeft
+// if it has no Alignment
+func (t *Table) alignCell(colI int, str string, width uint16) string {
+	al := t.Columns[colI].Alignment
+	if al == nil {
+		al = AlignmentLeft
+	}
+	return al(str, width)
+}
+
 func (t *Table) TableWidth(margin uint16) uint16 {
 	width := (uint16(t.ColumnCount()) - 1) * margin
 	for _, col := range t.Columns {
@@ -165,3 +340,14 @@ func (t *Table) TableWidth(margin uint16) uint16 {
 	}
 	return width
 }
+
+// FormattedItems is a simple FormattedItemList of rows returned by FormatItem
+type FormattedItems [][]string
+
+func (items FormattedItems) Len() int {
+	return len(items)
+}
+
+func (items FormattedItems) Get(index int) []string {
+	return items[index]
+}
@@ -10,3 +10,9 @@ type Getter interface {
 	ValueString(colName string, item any) (string, error)
 	Format(item any, value any) (string, error)
 }
+
+// LinkGetter can be implemented by a Getter to turn cells of its column
+// into hyperlinks, Link returns "" for cells without a link
+type LinkGetter interface {
+	Link(item any) (string, error)
+}
@@ -0,0 +1,19 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+
+package table
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+)
+
+func makeRaw(fd int) (func() error, error) {
+	return nil, fmt.Errorf("raw terminal mode is not supported on %v", runtime.GOOS)
+}
+
+func terminalSize(fd int) (int, int, error) {
+	return 0, 0, fmt.Errorf("terminal size is not supported on %v", runtime.GOOS)
+}
+
+func notifyResize(c chan<- os.Signal) {}
@@ -0,0 +1,215 @@
+package table
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Theme controls styles of header, rows (zebra striping), MergeRows*
+// group separators and borders of WriteBordered.
+// Styles are only applied when colors are enabled, see Table.SetColorLevel
+type Theme struct {
+	Name      string
+	Header    Style
+	EvenRow   Style
+	OddRow    Style
+	Separator Style
+	Border    Style
+}
+
+var ThemeLight = &Theme{
+	Name:      "light",
+	Header:    Style{Bold: true, Fg: Color256(18)},
+	OddRow:    Style{Bg: Color256(255)},
+	Separator: Style{Fg: Color256(250)},
+	Border:    Style{Fg: Color256(244)},
+}
+
+var ThemeDark = &Theme{
+	Name:      "dark",
+	Header:    Style{Bold: true, Fg: Color256(117)},
+	OddRow:    Style{Bg: Color256(236)},
+	Separator: Style{Fg: Color256(240)},
+	Border:    Style{Fg: Color256(242)},
+}
+
+var ThemeMonochrome = &Theme{
+	Name:   "monochrome",
+	Header: Style{Bold: true, Underline: true},
+	OddRow: Style{Inverse: true},
+}
+
+var themeByName = map[string]*Theme{
+	ThemeLight.Name:      ThemeLight,
+	ThemeDark.Name:       ThemeDark,
+	ThemeMonochrome.Name: ThemeMonochrome,
+}
+
+// RegisterTheme makes theme available by ThemeByName
+func RegisterTheme(theme *Theme) {
+	themeByName[theme.Name] = theme
+}
+
+// ThemeByName returns a built-in or registered theme, or nil
+func ThemeByName(name string) *Theme {
+	return themeByName[name]
+}
+
+func (t *Table) SetTheme(theme *Theme) {
+	t.Theme = theme
+}
+
+func (t *Table) themeStyle(get func(theme *Theme) Style) Style {
+	if t.Theme == nil {
+		return Style{}
+	}
+	return get(t.Theme)
+}
+
+func (t *Table) styledSeparator(sep string) string {
+	style := t.themeStyle(func(theme *Theme) Style { return theme.Separator })
+	return style.apply(sep, t.outputColorLevel())
+}
+
+// styleRow applies zebra striping of Theme to an output line
+func (t *Table) styleRow(line string, rowIndex int) string {
+	style := t.themeStyle(func(theme *Theme) Style {
+		if rowIndex%2 == 1 {
+			return theme.OddRow
+		}
+		return theme.EvenRow
+	})
+	return style.apply(line, t.outputColorLevel())
+}
+
+// LoadTheme reads a theme config file, see ParseTheme
+func LoadTheme(path string) (*Theme, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ParseTheme(file)
+}
+
+// ParseTheme parses a theme config with one "key = value" per line.
+// Keys are: name, header, even_row, odd_row, separator, border.
+// Values (except for name) are space-separated attributes:
+// bold, underline, inverse, fg=COLOR and bg=COLOR, where COLOR is a color
+// name (red, bright-red, ...), a 256-color code (0-255) or #rrggbb.
+// Lines starting with # are comments.
+func ParseTheme(reader io.Reader) (*Theme, error) {
+	theme := &Theme{}
+	scanner := bufio.NewScanner(reader)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: missing '='", lineNum)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if key == "name" {
+			theme.Name = value
+			continue
+		}
+		var target *Style
+		switch key {
+		case "header":
+			target = &theme.Header
+		case "even_row":
+			target = &theme.EvenRow
+		case "odd_row":
+			target = &theme.OddRow
+		case "separator":
+			target = &theme.Separator
+		case "border":
+			target = &theme.Border
+		default:
+			return nil, fmt.Errorf("line %d: unknown key %#v", lineNum, key)
+		}
+		style, err := ParseStyle(value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		*target = style
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return theme, nil
+}
+
+// ParseStyle parses space-separated style attributes, see ParseTheme
+func ParseStyle(str string) (Style, error) {
+	style := Style{}
+	for _, attr := range strings.Fields(str) {
+		switch attr {
+		case "bold":
+			style.Bold = true
+			continue
+		case "underline":
+			style.Underline = true
+			continue
+		case "inverse":
+			style.Inverse = true
+			continue
+		}
+		key, value, ok := strings.Cut(attr, "=")
+		if !ok || (key != "fg" && key != "bg") {
+			return Style{}, fmt.Errorf("invalid style attribute %#v", attr)
+		}
+		color, err := ParseColor(value)
+		if err != nil {
+			return Style{}, err
+		}
+		if key == "fg" {
+			style.Fg = color
+		} else {
+			style.Bg = color
+		}
+	}
+	return style, nil
+}
+
+var colorNames = []string{
+	"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white",
+}
+
+// ParseColor parses a color name (red, bright-red, ...), a 256-color
+// code (0-255) or a truecolor in #rrggbb form
+func ParseColor(str string) (Color, error) {
+	if strings.HasPrefix(str, "#") {
+		if len(str) != 7 {
+			return Color{}, fmt.Errorf("invalid color %#v", str)
+		}
+		n, err := strconv.ParseUint(str[1:], 16, 32)
+		if err != nil {
+			return Color{}, fmt.Errorf("invalid color %#v", str)
+		}
+		return RGB(uint8(n>>16), uint8(n>>8), uint8(n)), nil
+	}
+	if n, err := strconv.ParseUint(str, 10, 8); err == nil {
+		return Color256(uint8(n)), nil
+	}
+	name := strings.TrimPrefix(str, "bright-")
+	for i, colorName := range colorNames {
+		if name != colorName {
+			continue
+		}
+		if name != str {
+			return Color16(uint8(i + 8)), nil
+		}
+		return Color16(uint8(i)), nil
+	}
+	return Color{}, fmt.Errorf("invalid color %#v", str)
+}
@@ -0,0 +1,71 @@
+package table
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestParseTheme(t *testing.T) {
+	is := is.New(t)
+	theme, err := ParseTheme(strings.NewReader(`
+# my theme
+name = ocean
+header = bold underline fg=bright-cyan
+odd_row = bg=236
+separator = fg=#ff8000
+`))
+	is.NotErr(err)
+	is.Equal(theme, &Theme{
+		Name:      "ocean",
+		Header:    Style{Bold: true, Underline: true, Fg: Color16(14)},
+		OddRow:    Style{Bg: Color256(236)},
+		Separator: Style{Fg: RGB(255, 128, 0)},
+	})
+
+	_, err = ParseTheme(strings.NewReader("header = fg=nocolor"))
+	is.Err(err)
+}
+
+func TestColorDowngrade(t *testing.T) {
+	is := is.New(t)
+	style := Style{Fg: RGB(255, 0, 0)}
+	is.Equal(style.sgr(ColorLevelTrue), "\x1b[38;2;255;0;0m")
+	is.Equal(style.sgr(ColorLevel256), "\x1b[38;5;196m")
+	is.Equal(style.sgr(ColorLevel16), "\x1b[91m")
+	is.Equal(style.sgr(ColorLevelNone), "")
+	is.Equal(Style{Fg: RGB(128, 128, 128)}.sgr(ColorLevel256), "\x1b[38;5;244m")
+}
+
+func TestWriteBordered(t *testing.T) {
+	is := is.New(t)
+	tab := newTestTable("name", "size")
+	tab.Columns[1].Alignment = AlignmentRight
+	tab.SetColor(false)
+	items := FormattedItems{}
+	for _, item := range [][]any{{"さの.png", 12}, {"a.txt", 1024}} {
+		formatted, err := tab.FormatItem(item)
+		is.NotErr(err)
+		items = append(items, formatted)
+	}
+	out := &strings.Builder{}
+	is.NotErr(tab.WriteBordered(out, items, BorderASCII))
+	is.Equal(out.String(), `+----------+------+
+|   name   | size |
++----------+------+
+| さの.png |   12 |
+| a.txt    | 1024 |
++----------+------+
+`)
+
+	tab.SetTheme(ThemeMonochrome)
+	tab.SetColor(true)
+	out.Reset()
+	is.NotErr(tab.WritePlain(out, items, " "))
+	is.Equal(out.String(), ""+
+		"\x1b[1;4m  name  \x1b[0m \x1b[1;4msize\x1b[0m\n"+
+		"さの.png   12\n"+
+		"\x1b[7ma.txt    1024\x1b[0m\n",
+	)
+}
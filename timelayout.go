@@ -0,0 +1,98 @@
+package table
+
+type layoutElemKind uint8
+
+const (
+	layoutLiteral layoutElemKind = iota
+	layoutZone
+	layoutYear
+	layoutYear2
+	layoutMonthLong
+	layoutMonthShort
+	layoutMonth2
+	layoutMonth
+	layoutDay2
+	layoutDay
+	layoutWeekdayLong
+	layoutWeekdayShort
+	layoutHour24
+	layoutHour12Pad
+	layoutHour12
+	layoutMinute2
+	layoutSecond2
+	layoutFraction
+	layoutPM
+	layoutPMLower
+)
+
+// layoutElem is an element of a Go time layout, literal is only set
+// for layoutLiteral
+type layoutElem struct {
+	literal string
+	kind    layoutElemKind
+}
+
+// goLayoutElems are elements of Go time layouts, longer elements
+// must come first
+var goLayoutElems = []struct {
+	layout string
+	kind   layoutElemKind
+}{
+	{"Z07:00", layoutZone},
+	{"Z0700", layoutZone},
+	{"-07:00", layoutZone},
+	{"-0700", layoutZone},
+	{"-07", layoutZone},
+	{"MST", layoutZone},
+	{"January", layoutMonthLong},
+	{"Monday", layoutWeekdayLong},
+	{"2006", layoutYear},
+	{"Jan", layoutMonthShort},
+	{"Mon", layoutWeekdayShort},
+	{".000000000", layoutFraction},
+	{".000000", layoutFraction},
+	{".000", layoutFraction},
+	{"01", layoutMonth2},
+	{"02", layoutDay2},
+	{"03", layoutHour12Pad},
+	{"04", layoutMinute2},
+	{"05", layoutSecond2},
+	{"06", layoutYear2},
+	{"15", layoutHour24},
+	{"PM", layoutPM},
+	{"pm", layoutPMLower},
+	{"1", layoutMonth},
+	{"2", layoutDay},
+	{"3", layoutHour12},
+}
+
+// splitTimeLayout splits a Go time layout (like TableSpec.TimeFormat)
+// into elements, for converting it to date formats of other formats
+func splitTimeLayout(layout string) []layoutElem {
+	elems := []layoutElem{}
+	literal := ""
+	for layout != "" {
+		matched := false
+		for _, le := range goLayoutElems {
+			if len(layout) < len(le.layout) || layout[:len(le.layout)] != le.layout {
+				continue
+			}
+			if literal != "" {
+				elems = append(elems, layoutElem{kind: layoutLiteral, literal: literal})
+				literal = ""
+			}
+			elems = append(elems, layoutElem{kind: le.kind})
+			layout = layout[len(le.layout):]
+			matched = true
+			break
+		}
+		if !matched {
+			literal += layout[:1]
+			layout = layout[1:]
+		}
+	}
+	if literal != "" {
+		elems = append(elems, layoutElem{kind: layoutLiteral, literal: literal})
+	}
+	return elems
+}
@@ -0,0 +1,157 @@
+package table
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func tomlBareKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, c := range key {
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9', c == '_', c == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func tomlKey(key string) string {
+	if tomlBareKey(key) {
+		return key
+	}
+	return tomlQuote(key, false)
+}
+
+// tomlQuote returns str as a basic string, or as a multi-line basic
+// string if multiLine is true
+func tomlQuote(str string, multiLine bool) string {
+	var sb strings.Builder
+	if multiLine {
+		// a newline right after the opening delimiter is trimmed
+		sb.WriteString("\"\"\"\n")
+	} else {
+		sb.WriteByte('"')
+	}
+	for _, r := range str {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			if multiLine {
+				sb.WriteByte('\n')
+			} else {
+				sb.WriteString(`\n`)
+			}
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\r':
+			sb.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&sb, `\u%04X`, r)
+				continue
+			}
+			sb.WriteRune(r)
+		}
+	}
+	if multiLine {
+		sb.WriteString(`"""`)
+	} else {
+		sb.WriteByte('"')
+	}
+	return sb.String()
+}
+
+func tomlString(str string) string {
+	return tomlQuote(str, strings.Contains(str, "\n"))
+}
+
+// tomlValue returns TOML value of a value returned by Getter.Value,
+// ok is false for nil which has no TOML representation
+func tomlValue(value any) (string, bool) {
+	value = sqlDeref(value)
+	switch v := value.(type) {
+	case nil:
+		return "", false
+	case time.Time:
+		return v.Format(time.RFC3339Nano), true
+	case time.Duration:
+		return tomlString(v.String()), true
+	case []byte:
+		return tomlString(base64.StdEncoding.EncodeToString(v)), true
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n := rv.Uint()
+		if n > math.MaxInt64 {
+			// TOML integers are 64-bit signed
+			return tomlString(strconv.FormatUint(n, 10)), true
+		}
+		return strconv.FormatUint(n, 10), true
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		switch {
+		case math.IsNaN(f):
+			return "nan", true
+		case math.IsInf(f, 1):
+			return "inf", true
+		case math.IsInf(f, -1):
+			return "-inf", true
+		}
+		str := strconv.FormatFloat(f, 'g', -1, 64)
+		if !strings.ContainsAny(str, ".eE") {
+			str += ".0"
+		}
+		return str, true
+	case reflect.String:
+		return tomlString(rv.String()), true
+	}
+	return tomlString(fmt.Sprint(value)), true
+}
+
+// WriteTOML writes items as a TOML array of tables named arrayName,
+// keyed by Column.Name. Nil values are omitted since TOML has no null
+func (t *Table) WriteTOML(out io.Writer, items []any, arrayName string) error {
+	var sb strings.Builder
+	header := "[[" + tomlKey(arrayName) + "]]\n"
+	keys := make([]string, t.ColumnCount())
+	for i, col := range t.Columns {
+		keys[i] = tomlKey(col.Name)
+	}
+	for itemI, item := range items {
+		if itemI > 0 {
+			sb.WriteByte('\n')
+		}
+		sb.WriteString(header)
+		for i, col := range t.Columns {
+			value, err := col.Getter.Value(item)
+			if err != nil {
+				return err
+			}
+			str, ok := tomlValue(value)
+			if !ok {
+				continue
+			}
+			sb.WriteString(keys[i] + " = " + str + "\n")
+		}
+	}
+	_, err := io.WriteString(out, sb.String())
+	return err
+}
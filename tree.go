@@ -0,0 +1,242 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// tree-drawing prefixes of items, by whether the item (or an ancestor)
+// is the last child of its parent
+const (
+	treeBranch     = "├─ "
+	treeLastBranch = "└─ "
+	treeLine       = "│  "
+	treeSpace      = "   "
+)
+
+// Aggregate rolls up values of an item and its children into one value,
+// nil values are not passed
+type Aggregate func(values []any) any
+
+// AggregateSum returns the sum of numbers of the kind of the first value
+// (other values are skipped), with the type of the first value (like
+// int64, float64, time.Duration or ByteSize)
+func AggregateSum(values []any) any {
+	if len(values) == 0 {
+		return nil
+	}
+	first := reflect.ValueOf(values[0])
+	switch first.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		sum := int64(0)
+		for _, value := range values {
+			if rv := reflect.ValueOf(value); rv.CanInt() {
+				sum += rv.Int()
+			}
+		}
+		return reflect.ValueOf(sum).Convert(first.Type()).Interface()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		sum := uint64(0)
+		for _, value := range values {
+			if rv := reflect.ValueOf(value); rv.CanUint() {
+				sum += rv.Uint()
+			}
+		}
+		return reflect.ValueOf(sum).Convert(first.Type()).Interface()
+	case reflect.Float32, reflect.Float64:
+		sum := 0.0
+		for _, value := range values {
+			if rv := reflect.ValueOf(value); rv.CanFloat() {
+				sum += rv.Float()
+			}
+		}
+		return reflect.ValueOf(sum).Convert(first.Type()).Interface()
+	}
+	return values[0]
+}
+
+// AggregateMax returns the maximum value, compared by CompareValues
+func AggregateMax(values []any) any {
+	var result any
+	for _, value := range values {
+		if result == nil || CompareValues(value, result) > 0 {
+			result = value
+		}
+	}
+	return result
+}
+
+// AggregateMin returns the minimum value, compared by CompareValues
+func AggregateMin(values []any) any {
+	var result any
+	for _, value := range values {
+		if result == nil || CompareValues(value, result) < 0 {
+			result = value
+		}
+	}
+	return result
+}
+
+// TreeOptions are options of FormatTree
+type TreeOptions struct {
+	// Children returns children of item, it is required
+	Children func(item any) []any
+	// Column is the name of the column prefixed with tree-drawing
+	// glyphs, default is the first column
+	Column string
+	// MaxDepth hides items deeper than MaxDepth (roots have depth 1),
+	// 0 means no limit. Hidden items are still rolled up to parents
+	MaxDepth int
+	// Aggregates by column name roll up values of children to parents:
+	// the value of an item with children is the aggregate of its own
+	// value and the rolled up values of its children
+	Aggregates map[string]Aggregate
+}
+
+// treeFormatter formats a tree of items into rows
+type treeFormatter struct {
+	table *Table
+	opts  *TreeOptions
+	colI  int
+	rows  FormattedItems
+	// ancestors are keys of items whose children are being formatted
+	ancestors map[treeKey]bool
+}
+
+// treeKey identifies an item for detecting cycles, by address for
+// pointers, maps and slices, and by value for booleans, numbers and strings
+type treeKey struct {
+	typ   reflect.Type
+	ptr   uintptr
+	len   int
+	value any
+}
+
+// newTreeKey returns the key of item, ok is false for other kinds of items
+func newTreeKey(item any) (key treeKey, ok bool) {
+	rv := reflect.ValueOf(item)
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return key, false
+	case reflect.Pointer, reflect.Map:
+		return treeKey{typ: rv.Type(), ptr: rv.Pointer()}, true
+	case reflect.Slice:
+		return treeKey{typ: rv.Type(), ptr: rv.Pointer(), len: rv.Len()}, true
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return treeKey{typ: rv.Type(), value: item}, true
+	}
+	return key, false
+}
+
+// values returns values of columns of item, rolled up from children,
+// and appends rows of item and its children (if shown) to tf.rows
+func (tf *treeFormatter) values(item any, depth int, prefix string, last bool) ([]any, error) {
+	t := tf.table
+	values := make([]any, t.ColumnCount())
+	for i, col := range t.Columns {
+		value, err := col.Getter.Value(item)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	shown := tf.opts.MaxDepth <= 0 || depth <= tf.opts.MaxDepth
+	rowI := len(tf.rows)
+	if shown {
+		// placeholder, formatted after children are rolled up
+		tf.rows = append(tf.rows, nil)
+	}
+	childPrefix := ""
+	itemPrefix := ""
+	if depth > 1 {
+		itemPrefix = prefix + treeBranch
+		childPrefix = prefix + treeLine
+		if last {
+			itemPrefix = prefix + treeLastBranch
+			childPrefix = prefix + treeSpace
+		}
+	}
+	var children []any
+	if key, ok := newTreeKey(item); !ok {
+		children = tf.opts.Children(item)
+	} else if !tf.ancestors[key] {
+		children = tf.opts.Children(item)
+		tf.ancestors[key] = true
+		defer delete(tf.ancestors, key)
+	}
+	rolled := make([][]any, t.ColumnCount())
+	for i, child := range children {
+		childValues, err := tf.values(child, depth+1, childPrefix, i == len(children)-1)
+		if err != nil {
+			return nil, err
+		}
+		for colI, value := range childValues {
+			if value != nil {
+				rolled[colI] = append(rolled[colI], value)
+			}
+		}
+	}
+	if len(children) > 0 {
+		for i, col := range t.Columns {
+			aggregate := tf.opts.Aggregates[col.Name]
+			if aggregate == nil {
+				continue
+			}
+			if values[i] != nil {
+				rolled[i] = append([]any{values[i]}, rolled[i]...)
+			}
+			values[i] = aggregate(rolled[i])
+		}
+	}
+	if !shown {
+		return values, nil
+	}
+	formatted, err := t.formatValues(item, values)
+	if err != nil {
+		return nil, err
+	}
+	formatted[tf.colI] = itemPrefix + formatted[tf.colI]
+	name := t.Columns[tf.colI].Name
+	t.UpdateWidth(map[string]uint16{
+		name: visualWidth(formatted[tf.colI]),
+	})
+	tf.rows[rowI] = formatted
+	return values, nil
+}
+
+// FormatTree formats roots and their children (depth-first) like
+// FormatItem, the tree column of children is prefixed with tree-drawing
+// glyphs and its width includes the prefix. The tree column should be
+// left-aligned. An item that is its own ancestor is shown without its
+// children, items are compared by address for pointers, maps and slices,
+// and by value for booleans, numbers and strings. Children must avoid
+// other cycles (like directories reached again through symbolic links)
+func (t *Table) FormatTree(roots []any, opts *TreeOptions) (FormattedItems, error) {
+	if opts == nil || opts.Children == nil {
+		return nil, fmt.Errorf("TreeOptions.Children is not set")
+	}
+	tf := &treeFormatter{
+		table:     t,
+		opts:      opts,
+		rows:      FormattedItems{},
+		ancestors: map[treeKey]bool{},
+	}
+	if opts.Column != "" {
+		tf.colI = t.columnIndex(opts.Column)
+		if tf.colI < 0 {
+			return nil, fmt.Errorf("invalid tree column %#v", opts.Column)
+		}
+	}
+	if t.ColumnCount() == 0 {
+		return tf.rows, nil
+	}
+	for _, root := range roots {
+		if _, err := tf.values(root, 1, "", false); err != nil {
+			return nil, err
+		}
+	}
+	return tf.rows, nil
+}
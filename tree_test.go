@@ -0,0 +1,114 @@
+package table
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/ilius/is/v2"
+)
+
+// testTree returns a directory tree, items are []any{name, size, children}
+func testTree() []any {
+	dir := func(name string, children ...any) any {
+		return []any{name, ByteSize(4096), children}
+	}
+	file := func(name string, size ByteSize) any {
+		return []any{name, size, nil}
+	}
+	return []any{
+		dir("project",
+			dir("src",
+				file("main.go", 1200),
+				dir("internal",
+					file("util.go", 300),
+				),
+			),
+			file("README.md", 500),
+		),
+		file("notes.txt", 20),
+	}
+}
+
+func testTreeChildren(item any) []any {
+	children, _ := item.([]any)[2].([]any)
+	return children
+}
+
+func TestFormatTree(t *testing.T) {
+	is := is.New(t)
+	tab := newTestTable("name", "size")
+	tab.SetColor(false)
+	tab.ColumnByName["size"].Alignment = AlignmentRight
+	rows, err := tab.FormatTree(testTree(), &TreeOptions{
+		Children: testTreeChildren,
+	})
+	is.NotErr(err)
+	buf := &bytes.Buffer{}
+	is.NotErr(tab.WritePlain(buf, rows, "  "))
+	is.Equal(buf.String(), ""+
+		"      name        size\n"+
+		"project             4K\n"+
+		"├─ src              4K\n"+
+		"│  ├─ main.go     1.2K\n"+
+		"│  └─ internal      4K\n"+
+		"│     └─ util.go   300\n"+
+		"└─ README.md       500\n"+
+		"notes.txt           20\n")
+}
+
+func TestFormatTreeRollUp(t *testing.T) {
+	is := is.New(t)
+	tab := newTestTable("name", "size")
+	tab.SetColor(false)
+	tab.ColumnByName["size"].Alignment = AlignmentRight
+	rows, err := tab.FormatTree(testTree(), &TreeOptions{
+		Children:   testTreeChildren,
+		MaxDepth:   2,
+		Aggregates: map[string]Aggregate{"size": AggregateSum},
+	})
+	is.NotErr(err)
+	is.Equal(rows, FormattedItems{
+		{"project", "14K"},
+		{"├─ src", "9.5K"},
+		{"└─ README.md", "500"},
+		{"notes.txt", "20"},
+	})
+	is.Equal(tab.Width("name"), uint16(12))
+
+	_, err = tab.FormatTree(testTree(), &TreeOptions{Children: testTreeChildren, Column: "x"})
+	is.Err(err)
+	_, err = tab.FormatTree(testTree(), nil)
+	is.Err(err)
+}
+
+func TestFormatTreeCycle(t *testing.T) {
+	is := is.New(t)
+	tab := newTestTable("name", "size")
+	tab.SetColor(false)
+	root := []any{"root", 1, nil}
+	child := []any{"child", 2, []any{root}}
+	root[2] = []any{child}
+	rows, err := tab.FormatTree([]any{root}, &TreeOptions{
+		Children:   testTreeChildren,
+		Aggregates: map[string]Aggregate{"size": AggregateSum},
+	})
+	is.NotErr(err)
+	is.Equal(rows, FormattedItems{
+		{"root", "4"},
+		{"└─ child", "3"},
+		{"   └─ root", "1"},
+	})
+
+}
+
+func TestAggregates(t *testing.T) {
+	is := is.New(t)
+	is.Equal(AggregateSum([]any{int64(2), int64(3), 1.5}), int64(5))
+	is.Equal(AggregateSum([]any{1.5, 2.25}), 3.75)
+	is.Equal(AggregateSum([]any{time.Second, time.Minute}), 61*time.Second)
+	is.Equal(AggregateSum([]any{"a", "b"}), "a")
+	is.Equal(AggregateSum(nil), nil)
+	is.Equal(AggregateMax([]any{3, 7, 5}), 7)
+	is.Equal(AggregateMin([]any{"b", "a", "c"}), "a")
+}
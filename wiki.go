@@ -0,0 +1,113 @@
+package table
+
+import (
+	"html"
+	"io"
+	"strings"
+)
+
+var mediawikiEscaper = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	"|", "&#124;",
+	"{", "&#123;",
+	"}", "&#125;",
+	"[", "&#91;",
+	"]", "&#93;",
+)
+
+var jiraEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"|", `\|`,
+	"{", `\{`,
+	"}", `\}`,
+	"[", `\[`,
+	"]", `\]`,
+)
+
+// alignStyle returns an inline CSS text-align declaration for al
+// or "" for left / custom alignments
+func alignStyle(al Alignment) string {
+	switch name := alignmentName(al); name {
+	case "right", "center":
+		return "text-align: " + name + ";"
+	}
+	return ""
+}
+
+// WriteMediaWiki writes items as a MediaWiki table with class "wikitable"
+func (t *Table) WriteMediaWiki(out io.Writer, items []any) error {
+	header, rows, _, err := t.textCells(items, mediawikiEscaper.Replace)
+	if err != nil {
+		return err
+	}
+	cellLine := func(marker string, colI int, cell string) string {
+		if style := alignStyle(t.Columns[colI].Alignment); style != "" {
+			return marker + ` style="` + style + `" | ` + cell
+		}
+		return strings.TrimRight(marker+" "+cell, " ")
+	}
+	lines := []string{`{| class="wikitable"`, "|-"}
+	for i, cell := range header {
+		lines = append(lines, cellLine("!", i, cell))
+	}
+	for _, cells := range rows {
+		lines = append(lines, "|-")
+		for i, cell := range cells {
+			lines = append(lines, cellLine("|", i, cell))
+		}
+	}
+	lines = append(lines, "|}")
+	_, err = io.WriteString(out, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+// WriteJira writes items in Jira wiki markup, ||header|| and |cell|.
+// Jira tables have no alignment markup, so Column.Alignment is ignored
+func (t *Table) WriteJira(out io.Writer, items []any) error {
+	header, rows, _, err := t.textCells(items, jiraEscaper.Replace)
+	if err != nil {
+		return err
+	}
+	lines := []string{"||" + strings.Join(header, "||") + "||"}
+	for _, cells := range rows {
+		for i, cell := range cells {
+			if cell == "" {
+				// "||" would start a header cell
+				cells[i] = " "
+			}
+		}
+		lines = append(lines, "|"+strings.Join(cells, "|")+"|")
+	}
+	_, err = io.WriteString(out, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+// WriteConfluence writes items as a table in Confluence storage format (XHTML)
+func (t *Table) WriteConfluence(out io.Writer, items []any) error {
+	header, rows, _, err := t.textCells(items, html.EscapeString)
+	if err != nil {
+		return err
+	}
+	cellElem := func(tag string, colI int, cell string) string {
+		if style := alignStyle(t.Columns[colI].Alignment); style != "" {
+			return "<" + tag + ` style="` + style + `">` + cell + "</" + tag + ">"
+		}
+		return "<" + tag + ">" + cell + "</" + tag + ">"
+	}
+	row := func(tag string, cells []string) string {
+		elems := make([]string, len(cells))
+		for i, cell := range cells {
+			elems[i] = cellElem(tag, i, cell)
+		}
+		return "<tr>" + strings.Join(elems, "") + "</tr>"
+	}
+	lines := []string{"<table><tbody>", row("th", header)}
+	for _, cells := range rows {
+		lines = append(lines, row("td", cells))
+	}
+	lines = append(lines, "</tbody></table>")
+	_, err = io.WriteString(out, strings.Join(lines, "\n")+"\n")
+	return err
+}
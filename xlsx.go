@@ -0,0 +1,388 @@
+package table
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type sheetCellKind uint8
+
+const (
+	sheetEmpty sheetCellKind = iota
+	sheetString
+	sheetNumber
+	sheetBool
+	sheetTime
+	sheetDuration
+)
+
+// sheetCell is a typed cell value for spreadsheet writers
+type sheetCell struct {
+	time     time.Time
+	str      string
+	num      float64
+	duration time.Duration
+	kind     sheetCellKind
+	boolean  bool
+}
+
+// sheetRow returns typed cells of item from Getter.Value, values that are
+// not numbers, booleans, times or durations (and NaN or infinite numbers)
+// are formatted by Getter.Format
+func (t *Table) sheetRow(item any) ([]sheetCell, error) {
+	cells := make([]sheetCell, t.ColumnCount())
+	for i, col := range t.Columns {
+		value, err := col.Getter.Value(item)
+		if err != nil {
+			return nil, err
+		}
+		value = sqlDeref(value)
+		switch v := value.(type) {
+		case nil:
+			continue
+		case time.Time:
+			cells[i] = sheetCell{kind: sheetTime, time: v}
+			continue
+		case time.Duration:
+			cells[i] = sheetCell{kind: sheetDuration, duration: v}
+			continue
+		}
+		rv := reflect.ValueOf(value)
+		switch rv.Kind() {
+		case reflect.Bool:
+			cells[i] = sheetCell{kind: sheetBool, boolean: rv.Bool()}
+			continue
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			// NaN and infinities are not valid numbers in spreadsheets
+			num, _ := toFloat64(value)
+			if !math.IsNaN(num) && !math.IsInf(num, 0) {
+				cells[i] = sheetCell{kind: sheetNumber, num: num}
+				continue
+			}
+		}
+		formatted, err := col.Getter.Format(item, value)
+		if err != nil {
+			return nil, err
+		}
+		cells[i] = sheetCell{kind: sheetString, str: StripANSI(formatted)}
+	}
+	return cells, nil
+}
+
+var excelLayoutCodes = map[layoutElemKind]string{
+	layoutYear:         "yyyy",
+	layoutYear2:        "yy",
+	layoutMonthLong:    "mmmm",
+	layoutMonthShort:   "mmm",
+	layoutMonth2:       "mm",
+	layoutMonth:        "m",
+	layoutDay2:         "dd",
+	layoutDay:          "d",
+	layoutWeekdayLong:  "dddd",
+	layoutWeekdayShort: "ddd",
+	layoutHour24:       "hh",
+	layoutHour12Pad:    "hh",
+	layoutHour12:       "h",
+	layoutMinute2:      "mm",
+	layoutSecond2:      "ss",
+	layoutFraction:     ".000",
+	layoutPM:           "AM/PM",
+	layoutPMLower:      "am/pm",
+}
+
+const defaultSheetTimeFormat = "yyyy-mm-dd hh:mm:ss"
+
+// excelTimeFormat converts a Go time layout (like TableSpec.TimeFormat)
+// to a spreadsheet number format code, time zone elements are dropped
+// since spreadsheet dates have no time zone
+func excelTimeFormat(layout string) string {
+	if layout == "" {
+		return defaultSheetTimeFormat
+	}
+	var sb strings.Builder
+	for _, elem := range splitTimeLayout(layout) {
+		if elem.kind != layoutLiteral {
+			sb.WriteString(excelLayoutCodes[elem.kind])
+			continue
+		}
+		for _, c := range elem.literal {
+			switch c {
+			case ' ', ':', '/', '.', ',', '-':
+				sb.WriteRune(c)
+			default:
+				sb.WriteString(`\`)
+				sb.WriteRune(c)
+			}
+		}
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+var spreadsheetEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// excelSerial returns the spreadsheet serial number (days since 1899-12-30)
+// of the wall clock time of t
+func excelSerial(t time.Time) float64 {
+	wall := time.Date(
+		t.Year(), t.Month(), t.Day(),
+		t.Hour(), t.Minute(), t.Second(), t.Nanosecond(),
+		time.UTC,
+	)
+	return wall.Sub(spreadsheetEpoch).Hours() / 24
+}
+
+// excelColumnName returns column letters of zero-based index colI: A, B, ..., AA
+func excelColumnName(colI int) string {
+	name := ""
+	for n := colI + 1; n > 0; n = (n - 1) / 26 {
+		name = string(rune('A'+(n-1)%26)) + name
+	}
+	return name
+}
+
+func xmlEscape(str string) string {
+	var sb strings.Builder
+	_ = xml.EscapeText(&sb, []byte(str))
+	return sb.String()
+}
+
+// Sheet is one sheet of a spreadsheet, see WriteXLSX and WriteODS
+type Sheet struct {
+	Table *Table
+	Name  string
+	Items []any
+}
+
+var invalidSheetNameChars = strings.NewReplacer(
+	"[", "_", "]", "_", ":", "_", "*", "_", "?", "_", "/", "_", `\`, "_",
+)
+
+func sheetName(name string, index int) string {
+	name = invalidSheetNameChars.Replace(name)
+	if name == "" {
+		name = "Sheet" + strconv.Itoa(index+1)
+	}
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	return name
+}
+
+// cell style indexes in xlsxStyles, date style of sheet i is
+// xlsxStyleDate + i
+const (
+	xlsxStyleDefault  = 0
+	xlsxStyleHeader   = 1
+	xlsxStyleDuration = 2
+	xlsxStyleDate     = 3
+)
+
+// xlsxStyles returns styles part, with one date format per sheet
+func xlsxStyles(timeFormats []string) string {
+	numFmts := `<numFmt numFmtId="164" formatCode="[h]:mm:ss"/>`
+	dateXfs := ""
+	for i, format := range timeFormats {
+		id := strconv.Itoa(165 + i)
+		numFmts += `<numFmt numFmtId="` + id + `" formatCode="` + xmlEscape(format) + `"/>`
+		dateXfs += `<xf numFmtId="` + id + `" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>`
+	}
+	return xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<numFmts count="` + strconv.Itoa(len(timeFormats)+1) + `">` + numFmts + `</numFmts>` +
+		`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font>` +
+		`<font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		`<fills count="2"><fill><patternFill patternType="none"/></fill>` +
+		`<fill><patternFill patternType="gray125"/></fill></fills>` +
+		`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+		`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+		`<cellXfs count="` + strconv.Itoa(len(timeFormats)+3) + `">` +
+		`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+		`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+		`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+		dateXfs +
+		`</cellXfs>` +
+		`</styleSheet>`
+}
+
+func xlsxCell(ref string, cell sheetCell, dateStyle int) string {
+	switch cell.kind {
+	case sheetString:
+		return `<c r="` + ref + `" t="inlineStr"><is><t xml:space="preserve">` +
+			xmlEscape(cell.str) + `</t></is></c>`
+	case sheetNumber:
+		return `<c r="` + ref + `"><v>` + strconv.FormatFloat(cell.num, 'g', -1, 64) + `</v></c>`
+	case sheetBool:
+		v := "0"
+		if cell.boolean {
+			v = "1"
+		}
+		return `<c r="` + ref + `" t="b"><v>` + v + `</v></c>`
+	case sheetTime:
+		return fmt.Sprintf(`<c r="%s" s="%d"><v>%s</v></c>`,
+			ref, dateStyle, strconv.FormatFloat(excelSerial(cell.time), 'f', -1, 64))
+	case sheetDuration:
+		return fmt.Sprintf(`<c r="%s" s="%d"><v>%s</v></c>`,
+			ref, xlsxStyleDuration, strconv.FormatFloat(cell.duration.Hours()/24, 'f', -1, 64))
+	}
+	return ""
+}
+
+// xlsxWorksheet returns the worksheet XML and the autofilter range
+func xlsxWorksheet(sheet *Sheet, dateStyle int) (string, string, error) {
+	t := sheet.Table
+	colN := t.ColumnCount()
+	lastCol := excelColumnName(colN - 1)
+	filterRef := "A1:" + lastCol + strconv.Itoa(len(sheet.Items)+1)
+	var sb strings.Builder
+	sb.WriteString(xml.Header)
+	sb.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	sb.WriteString(`<dimension ref="` + filterRef + `"/>`)
+	sb.WriteString(`<sheetViews><sheetView workbookViewId="0">` +
+		`<pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/>` +
+		`</sheetView></sheetViews>`)
+	sb.WriteString("<cols>")
+	for i, col := range t.Columns {
+		width := t.Width(col.Name)
+		if titleWidth := visualWidth(col.Title); titleWidth > width {
+			width = titleWidth
+		}
+		sb.WriteString(fmt.Sprintf(`<col min="%d" max="%d" width="%d" customWidth="1"/>`, i+1, i+1, width+2))
+	}
+	sb.WriteString("</cols><sheetData>")
+	sb.WriteString(`<row r="1">`)
+	for i, col := range t.Columns {
+		sb.WriteString(fmt.Sprintf(
+			`<c r="%s1" t="inlineStr" s="%d"><is><t xml:space="preserve">%s</t></is></c>`,
+			excelColumnName(i), xlsxStyleHeader, xmlEscape(col.Title),
+		))
+	}
+	sb.WriteString("</row>")
+	for itemI, item := range sheet.Items {
+		cells, err := t.sheetRow(item)
+		if err != nil {
+			return "", "", err
+		}
+		rowNum := strconv.Itoa(itemI + 2)
+		sb.WriteString(`<row r="` + rowNum + `">`)
+		for colI, cell := range cells {
+			sb.WriteString(xlsxCell(excelColumnName(colI)+rowNum, cell, dateStyle))
+		}
+		sb.WriteString("</row>")
+	}
+	sb.WriteString("</sheetData>")
+	sb.WriteString(`<autoFilter ref="` + filterRef + `"/>`)
+	sb.WriteString("</worksheet>")
+	return sb.String(), filterRef, nil
+}
+
+func writeZipFile(zw *zip.Writer, name string, content string) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, content)
+	return err
+}
+
+// WriteXLSX writes an Office Open XML spreadsheet with one sheet per
+// Sheet. Cells are typed from Getter.Value, the header row is bold
+// and frozen, and has an autofilter. Date format of each sheet is
+// derived from TableSpec.TimeFormat of its table
+func WriteXLSX(out io.Writer, sheets ...*Sheet) error {
+	if len(sheets) == 0 {
+		return fmt.Errorf("no sheets")
+	}
+	zw := zip.NewWriter(out)
+	contentTypes := xml.Header +
+		`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`
+	workbookSheets := ""
+	definedNames := ""
+	timeFormats := []string{}
+	workbookRels := xml.Header +
+		`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rIdStyles" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`
+	for i, sheet := range sheets {
+		num := strconv.Itoa(i + 1)
+		name := sheetName(sheet.Name, i)
+		timeFormats = append(timeFormats, excelTimeFormat(sheet.Table.TimeFormat))
+		content, filterRef, err := xlsxWorksheet(sheet, xlsxStyleDate+i)
+		if err != nil {
+			return err
+		}
+		err = writeZipFile(zw, "xl/worksheets/sheet"+num+".xml", content)
+		if err != nil {
+			return err
+		}
+		contentTypes += `<Override PartName="/xl/worksheets/sheet` + num + `.xml" ` +
+			`ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`
+		workbookSheets += `<sheet name="` + xmlEscape(name) + `" sheetId="` + num + `" r:id="rId` + num + `"/>`
+		workbookRels += `<Relationship Id="rId` + num + `" ` +
+			`Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" ` +
+			`Target="worksheets/sheet` + num + `.xml"/>`
+		absRef := absoluteCellRange(filterRef)
+		definedNames += `<definedName name="_xlnm._FilterDatabase" localSheetId="` + strconv.Itoa(i) +
+			`" hidden="1">` + xmlEscape(`'`+strings.ReplaceAll(name, `'`, `''`)+`'!`+absRef) + `</definedName>`
+	}
+	contentTypes += `</Types>`
+	workbookRels += `</Relationships>`
+	workbook := xml.Header +
+		`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets>` + workbookSheets + `</sheets>` +
+		`<definedNames>` + definedNames + `</definedNames>` +
+		`</workbook>`
+	rootRels := xml.Header +
+		`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", contentTypes},
+		{"_rels/.rels", rootRels},
+		{"xl/workbook.xml", workbook},
+		{"xl/_rels/workbook.xml.rels", workbookRels},
+		{"xl/styles.xml", xlsxStyles(timeFormats)},
+	}
+	for _, file := range files {
+		if err := writeZipFile(zw, file.name, file.content); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// absoluteCellRange converts "A1:C10" to "$A$1:$C$10"
+func absoluteCellRange(ref string) string {
+	parts := strings.Split(ref, ":")
+	for i, part := range parts {
+		digitI := strings.IndexAny(part, "0123456789")
+		if digitI < 0 {
+			continue
+		}
+		parts[i] = "$" + part[:digitI] + "$" + part[digitI:]
+	}
+	return strings.Join(parts, ":")
+}
+
+// WriteXLSX writes items as a single sheet spreadsheet, see WriteXLSX
+func (t *Table) WriteXLSX(out io.Writer, items []any, sheetName string) error {
+	return WriteXLSX(out, &Sheet{
+		Table: t,
+		Name:  sheetName,
+		Items: items,
+	})
+}
@@ -0,0 +1,127 @@
+package table
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ilius/is/v2"
+)
+
+type xlsxTestSheet struct {
+	Cols []struct {
+		Width string `xml:"width,attr"`
+	} `xml:"cols>col"`
+	Rows []struct {
+		Cells []struct {
+			Ref    string `xml:"r,attr"`
+			Type   string `xml:"t,attr"`
+			Style  string `xml:"s,attr"`
+			Value  string `xml:"v"`
+			Inline string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+	Pane struct {
+		State string `xml:"state,attr"`
+		Split string `xml:"ySplit,attr"`
+	} `xml:"sheetViews>sheetView>pane"`
+	AutoFilter struct {
+		Ref string `xml:"ref,attr"`
+	} `xml:"autoFilter"`
+}
+
+func readZipFile(is *is.Is, zr *zip.Reader, name string) []byte {
+	file, err := zr.Open(name)
+	is.NotErr(err)
+	data, err := io.ReadAll(file)
+	is.NotErr(err)
+	return data
+}
+
+func TestWriteXLSX(t *testing.T) {
+	is := is.New(t)
+	tab := newTestTable("name", "size", "ok", "mtime", "age")
+	tab.TimeFormat = "2006-01-02 15:04"
+	mtime := time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC)
+	items := []any{
+		[]any{"a<b", 12, true, mtime, 36 * time.Hour},
+		[]any{"c", 1.5, false, nil, time.Duration(0)},
+	}
+	for _, item := range items {
+		_, err := tab.FormatItem(item)
+		is.NotErr(err)
+	}
+	buf := &bytes.Buffer{}
+	is.NotErr(tab.WriteXLSX(buf, items, "files"))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	is.NotErr(err)
+	for _, name := range []string{
+		"[Content_Types].xml",
+		"_rels/.rels",
+		"xl/workbook.xml",
+		"xl/_rels/workbook.xml.rels",
+		"xl/styles.xml",
+	} {
+		var doc struct{}
+		is.NotErr(xml.Unmarshal(readZipFile(is, zr, name), &doc))
+	}
+	is.True(bytes.Contains(
+		readZipFile(is, zr, "xl/styles.xml"),
+		[]byte(`formatCode="yyyy-mm-dd hh:mm"`),
+	))
+
+	sheet := xlsxTestSheet{}
+	is.NotErr(xml.Unmarshal(readZipFile(is, zr, "xl/worksheets/sheet1.xml"), &sheet))
+	is.Equal(sheet.Pane.State, "frozen")
+	is.Equal(sheet.Pane.Split, "1")
+	is.Equal(sheet.AutoFilter.Ref, "A1:E3")
+	is.Equal(len(sheet.Cols), 5)
+	is.Equal(sheet.Cols[0].Width, "6")
+	is.Equal(len(sheet.Rows), 3)
+	header := sheet.Rows[0].Cells
+	is.Equal(header[0].Inline, "name")
+	is.Equal(header[0].Style, "1")
+
+	row := sheet.Rows[1].Cells
+	is.Equal(row[0].Inline, "a<b")
+	is.Equal(row[0].Type, "inlineStr")
+	is.Equal(row[1].Value, "12")
+	is.Equal(row[2].Type, "b")
+	is.Equal(row[2].Value, "1")
+	is.Equal(row[3].Ref, "D2")
+	is.Equal(row[3].Value, "44928.5")
+	is.Equal(row[3].Style, "3")
+	is.Equal(row[4].Value, "1.5")
+	is.Equal(row[4].Style, "2")
+
+	// nil value has no cell
+	is.Equal(len(sheet.Rows[2].Cells), 4)
+}
+
+func TestSheetRowNaN(t *testing.T) {
+	is := is.New(t)
+	tab := newTestTable("a", "b", "c")
+	cells, err := tab.sheetRow([]any{math.NaN(), math.Inf(-1), 2.5})
+	is.NotErr(err)
+	is.Equal(cells, []sheetCell{
+		{kind: sheetString, str: "NaN"},
+		{kind: sheetString, str: "-Inf"},
+		{kind: sheetNumber, num: 2.5},
+	})
+}
+
+func TestExcelHelpers(t *testing.T) {
+	is := is.New(t)
+	is.Equal(excelColumnName(0), "A")
+	is.Equal(excelColumnName(25), "Z")
+	is.Equal(excelColumnName(26), "AA")
+	is.Equal(excelColumnName(701), "ZZ")
+	is.Equal(excelTimeFormat(time.RFC3339), `yyyy-mm-dd\Thh:mm:ss`)
+	is.Equal(excelTimeFormat("Jan 2, 2006 3:04 PM"), "mmm d, yyyy h:mm AM/PM")
+	is.Equal(absoluteCellRange("A1:AB10"), "$A$1:$AB$10")
+}
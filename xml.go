@@ -0,0 +1,154 @@
+package table
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// XMLOptions are options of WriteXML
+type XMLOptions struct {
+	// RootName is name of the root element, default "items"
+	RootName string
+	// ItemName is name of item elements, default "item"
+	ItemName string
+	// Indent is the indentation string, no indentation if empty
+	Indent string
+}
+
+// xmlName sanitizes name to be a valid XML element or attribute name
+func xmlName(name string) string {
+	var sb strings.Builder
+	for i, r := range name {
+		valid := unicode.IsLetter(r) || r == '_'
+		if i > 0 {
+			valid = valid || unicode.IsDigit(r) || r == '-' || r == '.'
+		}
+		if !valid {
+			if i == 0 && (unicode.IsDigit(r) || r == '-' || r == '.') {
+				sb.WriteRune('_')
+				sb.WriteRune(r)
+				continue
+			}
+			r = '_'
+		}
+		sb.WriteRune(r)
+	}
+	str := sb.String()
+	if str == "" || strings.HasPrefix(strings.ToLower(str), "xml") {
+		// names starting with "xml" are reserved
+		str = "_" + str
+	}
+	return str
+}
+
+// xmlNames returns sanitized names of columns, repeated names
+// get a suffix like "_2"
+func (t *Table) xmlNames() []xml.Name {
+	names := make([]xml.Name, t.ColumnCount())
+	used := map[string]bool{}
+	for i, col := range t.Columns {
+		base := xmlName(col.Name)
+		name := base
+		for n := 2; used[name]; n++ {
+			name = base + "_" + strconv.Itoa(n)
+		}
+		used[name] = true
+		names[i] = xml.Name{Local: name}
+	}
+	return names
+}
+
+// xmlValue returns the text of a cell, times are formatted with
+// TableSpec.TimeFormat (or RFC 3339) and others by Getter.ValueString.
+// ok is false for nil values
+func (t *Table) xmlValue(col *Column, item any) (string, bool, error) {
+	value, err := col.Getter.Value(item)
+	if err != nil {
+		return "", false, err
+	}
+	value = sqlDeref(value)
+	switch v := value.(type) {
+	case nil:
+		return "", false, nil
+	case time.Time:
+		layout := t.TimeFormat
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		return v.Format(layout), true, nil
+	}
+	str, err := col.Getter.ValueString(col.Name, item)
+	if err != nil {
+		return "", false, err
+	}
+	return StripANSI(str), true, nil
+}
+
+// WriteXML writes items as XML, one element per item with one child
+// element per column, or an attribute for columns with XMLAttribute.
+// Nil values are omitted
+func (t *Table) WriteXML(out io.Writer, items []any, opts *XMLOptions) error {
+	if opts == nil {
+		opts = &XMLOptions{}
+	}
+	rootName := xml.Name{Local: "items"}
+	if opts.RootName != "" {
+		rootName.Local = xmlName(opts.RootName)
+	}
+	itemName := xml.Name{Local: "item"}
+	if opts.ItemName != "" {
+		itemName.Local = xmlName(opts.ItemName)
+	}
+	names := t.xmlNames()
+	if _, err := io.WriteString(out, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(out)
+	enc.Indent("", opts.Indent)
+	if err := enc.EncodeToken(xml.StartElement{Name: rootName}); err != nil {
+		return err
+	}
+	for _, item := range items {
+		start := xml.StartElement{Name: itemName}
+		children := []xml.StartElement{}
+		texts := []string{}
+		for i, col := range t.Columns {
+			text, ok, err := t.xmlValue(col, item)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			if col.XMLAttribute {
+				start.Attr = append(start.Attr, xml.Attr{Name: names[i], Value: text})
+				continue
+			}
+			children = append(children, xml.StartElement{Name: names[i]})
+			texts = append(texts, text)
+		}
+		if err := enc.EncodeToken(start); err != nil {
+			return err
+		}
+		for i, child := range children {
+			if err := enc.EncodeElement(texts[i], child); err != nil {
+				return err
+			}
+		}
+		if err := enc.EncodeToken(start.End()); err != nil {
+			return err
+		}
+	}
+	if err := enc.EncodeToken(xml.EndElement{Name: rootName}); err != nil {
+		return err
+	}
+	if err := enc.Flush(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(out, "\n")
+	return err
+}
@@ -0,0 +1,56 @@
+package table
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestWriteXML(t *testing.T) {
+	is := is.New(t)
+	tab, items := newTestTableItems(recordsTestColumns, recordsTestRows...)
+	tab.TimeFormat = "2006-01-02"
+	tab.ColumnByName["name"].XMLAttribute = true
+	tab.ColumnByName["ok"].XMLAttribute = true
+	out := &strings.Builder{}
+	is.NotErr(tab.WriteXML(out, items[:2], &XMLOptions{
+		RootName: "files",
+		ItemName: "file",
+		Indent:   "  ",
+	}))
+	is.Equal(out.String(), `<?xml version="1.0" encoding="UTF-8"?>
+<files>
+  <file name="a.txt" ok="true">
+    <size>12</size>
+    <mtime>2023-01-02</mtime>
+    <note>line 1&#xA;line 2</note>
+    <my_key>1</my_key>
+  </file>
+  <file name="yes" ok="false">
+    <size>-1</size>
+    <mtime>2023-01-02</mtime>
+    <my_key>+Inf</my_key>
+  </file>
+</files>
+`)
+}
+
+func TestWriteXMLRepeatedNames(t *testing.T) {
+	is := is.New(t)
+	tab, items := newTestTableItems([]string{"a b", "a_b", "a/b", "c d", "c_d"}, []any{1, 2, 3, 4, 5})
+	tab.ColumnByName["a b"].XMLAttribute = true
+	tab.ColumnByName["a_b"].XMLAttribute = true
+	out := &strings.Builder{}
+	is.NotErr(tab.WriteXML(out, items, nil))
+	is.Equal(out.String(), xml.Header+
+		`<items><item a_b="1" a_b_2="2"><a_b_3>3</a_b_3><c_d>4</c_d><c_d_2>5</c_d_2></item></items>`+"\n")
+}
+
+func TestXMLName(t *testing.T) {
+	is := is.New(t)
+	is.Equal(xmlName("1st"), "_1st")
+	is.Equal(xmlName("xmlns"), "_xmlns")
+	is.Equal(xmlName("a b/c"), "a_b_c")
+}
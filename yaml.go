@@ -0,0 +1,201 @@
+package table
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// yamlReserved are plain scalars that YAML 1.1 or 1.2 resolve to
+// non-string values (in lower case)
+var yamlReserved = map[string]bool{
+	"~": true, "null": true,
+	"true": true, "false": true,
+	"yes": true, "no": true, "y": true, "n": true,
+	"on": true, "off": true,
+	".nan": true, ".inf": true, "-.inf": true, "+.inf": true,
+}
+
+// yamlPlainSafe returns true if str can be written as a plain scalar
+// and be read back as the same string
+func yamlPlainSafe(str string) bool {
+	if str == "" || yamlReserved[strings.ToLower(str)] {
+		return false
+	}
+	if str[0] == ' ' || str[len(str)-1] == ' ' || str[len(str)-1] == ':' {
+		return false
+	}
+	if strings.ContainsRune("-?:,[]{}#&*!|>'\"%@`", rune(str[0])) {
+		// "-x" is safe but "- x" and "-1" are not, keep it simple
+		return false
+	}
+	first := str[0]
+	if first >= '0' && first <= '9' || first == '+' || first == '.' {
+		// may be a number, date or time
+		return false
+	}
+	if strings.Contains(str, ": ") || strings.Contains(str, " #") {
+		return false
+	}
+	for _, r := range str {
+		if !unicode.IsPrint(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// yamlQuote returns str as a double-quoted scalar
+func yamlQuote(str string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range str {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\r':
+			sb.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&sb, `\x%02x`, r)
+				continue
+			}
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+// yamlBlock returns a multi-line str as a literal block scalar
+// indented by indent
+func yamlBlock(str string, indent string) string {
+	chomping := "-"
+	body := str
+	switch {
+	case strings.HasSuffix(str, "\n\n"):
+		chomping = "+"
+		body = str[:len(str)-1]
+	case strings.HasSuffix(str, "\n"):
+		chomping = ""
+		body = str[:len(str)-1]
+	}
+	header := "|"
+	if strings.HasPrefix(str, " ") || strings.HasPrefix(str, "\n") {
+		// indentation indicator, since it can not be detected
+		header += "2"
+	}
+	header += chomping
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = indent + "  " + line
+		}
+	}
+	return header + "\n" + strings.Join(lines, "\n")
+}
+
+// yamlString returns str as a plain, double-quoted or block scalar
+func yamlString(str string, indent string) string {
+	if strings.Contains(str, "\n") && !strings.ContainsAny(str, "\r\t") {
+		multiLine := true
+		for _, r := range str {
+			if r != '\n' && !unicode.IsPrint(r) {
+				multiLine = false
+				break
+			}
+		}
+		if multiLine {
+			return yamlBlock(str, indent)
+		}
+	}
+	if yamlPlainSafe(str) {
+		return str
+	}
+	return yamlQuote(str)
+}
+
+// yamlValue returns YAML scalar of a value returned by Getter.Value
+func yamlValue(value any, indent string) string {
+	value = sqlDeref(value)
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case time.Duration:
+		return yamlString(v.String(), indent)
+	case []byte:
+		return "!!binary " + base64.StdEncoding.EncodeToString(v)
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		switch {
+		case math.IsNaN(f):
+			return ".nan"
+		case math.IsInf(f, 1):
+			return ".inf"
+		case math.IsInf(f, -1):
+			return "-.inf"
+		}
+		str := strconv.FormatFloat(f, 'g', -1, 64)
+		if !strings.ContainsAny(str, ".eE") {
+			// keep it a float when read back
+			str += ".0"
+		}
+		return str
+	case reflect.String:
+		return yamlString(rv.String(), indent)
+	}
+	return yamlString(fmt.Sprint(value), indent)
+}
+
+// WriteYAML writes items as a YAML sequence of mappings keyed by
+// Column.Name, with typed values from Getter.Value
+func (t *Table) WriteYAML(out io.Writer, items []any) error {
+	if len(items) == 0 {
+		_, err := io.WriteString(out, "[]\n")
+		return err
+	}
+	var sb strings.Builder
+	keys := make([]string, t.ColumnCount())
+	for i, col := range t.Columns {
+		keys[i] = yamlString(col.Name, "")
+	}
+	for _, item := range items {
+		for i, col := range t.Columns {
+			value, err := col.Getter.Value(item)
+			if err != nil {
+				return err
+			}
+			if i == 0 {
+				sb.WriteString("- ")
+			} else {
+				sb.WriteString("  ")
+			}
+			sb.WriteString(keys[i] + ": " + yamlValue(value, "  ") + "\n")
+		}
+	}
+	_, err := io.WriteString(out, sb.String())
+	return err
+}